@@ -0,0 +1,77 @@
+package auth
+
+import "time"
+
+const (
+	RoleSeeker = "seeker"
+	RolePoster = "poster"
+	RoleAdmin  = "admin"
+)
+
+const (
+	PermissionJobsWrite         = "jobs:write"
+	PermissionApplicantsManage  = "applicants:manage"
+	PermissionApplicationsApply = "applications:apply"
+	PermissionApplicationsRead  = "applications:read"
+	PermissionAdminManage       = "admin:manage"
+)
+
+var rolePermissions = map[string][]string{
+	RoleSeeker: {PermissionApplicationsApply, PermissionApplicationsRead},
+	RolePoster: {PermissionJobsWrite, PermissionApplicantsManage},
+	RoleAdmin:  {PermissionJobsWrite, PermissionApplicantsManage, PermissionAdminManage},
+}
+
+// PrincipalKey is the gin context key the auth middleware stores the
+// Principal under.
+const PrincipalKey = "principal"
+
+// Principal is the authenticated caller a request acts on behalf of.
+type Principal struct {
+	UserId      uint
+	Role        string
+	Permissions []string
+	// EmailVerified is false until the user confirmed they own their email;
+	// unverified users may not post jobs or apply to them.
+	EmailVerified bool
+
+	// TokenId and TokenExpiresAt identify the access token the principal
+	// authenticated with, so it can be revoked on logout.
+	TokenId        string
+	TokenExpiresAt time.Time
+}
+
+func NewPrincipal(userId uint, role string) Principal {
+	return Principal{
+		UserId:      userId,
+		Role:        role,
+		Permissions: PermissionsOf(role),
+	}
+}
+
+// PermissionsOf returns the permissions granted to role, or none for an
+// unknown role.
+func PermissionsOf(role string) []string {
+	permissions := make([]string, len(rolePermissions[role]))
+	copy(permissions, rolePermissions[role])
+	return permissions
+}
+
+func (p Principal) Can(permission string) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// Owns reports whether the principal may act on a resource owned by ownerId.
+// Admins own everything.
+func (p Principal) Owns(ownerId uint) bool {
+	return p.IsAdmin() || (p.UserId != 0 && p.UserId == ownerId)
+}
@@ -0,0 +1,26 @@
+package auth_test
+
+import (
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal(t *testing.T) {
+	seeker := auth.NewPrincipal(1, auth.RoleSeeker)
+	poster := auth.NewPrincipal(2, auth.RolePoster)
+	admin := auth.NewPrincipal(3, auth.RoleAdmin)
+
+	assert.True(t, seeker.Can(auth.PermissionApplicationsApply))
+	assert.False(t, seeker.Can(auth.PermissionJobsWrite))
+	assert.True(t, poster.Can(auth.PermissionJobsWrite))
+	assert.False(t, poster.Can(auth.PermissionAdminManage))
+	assert.True(t, admin.Can(auth.PermissionAdminManage))
+
+	assert.True(t, poster.Owns(2))
+	assert.False(t, poster.Owns(1))
+	assert.True(t, admin.Owns(2))
+	assert.False(t, auth.Principal{}.Owns(0))
+	assert.Empty(t, auth.NewPrincipal(4, "unknown").Permissions)
+}
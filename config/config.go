@@ -0,0 +1,421 @@
+// Package config loads the application settings from defaults, an optional
+// env file, the process environment and command line flags, in that order of
+// precedence.
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+const DefaultFile = ".env"
+
+type Config struct {
+	Server    Server
+	Database  Database
+	JWT       JWT
+	Scheduler Scheduler
+	Password  Password
+	Notify    Notify
+	Email     Email
+}
+
+type Server struct {
+	Addr            string
+	RequestTimeout  time.Duration
+	ShutdownTimeout time.Duration
+}
+
+type Database struct {
+	URL           Secret
+	LogLevel      string
+	SlowThreshold time.Duration
+}
+
+type JWT struct {
+	Issuer          string
+	Algorithm       string
+	Secret          Secret
+	KeyDir          string
+	RotationPeriod  time.Duration
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+type Scheduler struct {
+	JobCloseInterval time.Duration
+	PurgeInterval    time.Duration
+	PurgeRetention   time.Duration
+}
+
+type Password struct {
+	BcryptCost int
+}
+
+// Bounds of the bcrypt cost, matching bcrypt.MinCost and bcrypt.MaxCost.
+const (
+	MinBcryptCost = 4
+	MaxBcryptCost = 31
+)
+
+type Notify struct {
+	Driver       string
+	File         string
+	From         string
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword Secret
+}
+
+const (
+	NotifyDriverLog  = "log"
+	NotifyDriverFile = "file"
+	NotifyDriverSMTP = "smtp"
+)
+
+// Email configures the signed links sent to verify an account's email.
+type Email struct {
+	VerifyURL    string
+	VerifySecret Secret
+	VerifyTTL    time.Duration
+}
+
+// Secret is a string that never prints its value. Use Value to read it.
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) Value() string {
+	return string(s)
+}
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string {
+	return fmt.Sprintf("config.Secret(%q)", s.String())
+}
+
+func (s Secret) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ValidationError lists every setting that failed validation.
+type ValidationError []string
+
+func (v ValidationError) Error() string {
+	return "invalid config: " + strings.Join(v, "; ")
+}
+
+func Default() Config {
+	return Config{
+		Server: Server{
+			Addr:            ":8080",
+			RequestTimeout:  5 * time.Second,
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Database: Database{
+			LogLevel:      "info",
+			SlowThreshold: time.Second,
+		},
+		JWT: JWT{
+			Issuer:          "job-portal",
+			Algorithm:       JWTAlgorithmHS256,
+			AccessTokenTTL:  time.Hour,
+			RefreshTokenTTL: 7 * 24 * time.Hour,
+		},
+		Scheduler: Scheduler{
+			JobCloseInterval: time.Minute,
+			PurgeInterval:    time.Hour,
+			PurgeRetention:   30 * 24 * time.Hour,
+		},
+		Password: Password{
+			BcryptCost: 12,
+		},
+		Notify: Notify{
+			Driver: NotifyDriverLog,
+			File:   "notifications.log",
+			From:   "no-reply@job-portal.local",
+		},
+		Email: Email{
+			VerifyURL: "http://localhost:8080/auth/verify-email",
+			VerifyTTL: 48 * time.Hour,
+		},
+	}
+}
+
+type setting struct {
+	env   string
+	flag  string
+	usage string
+	set   func(c *Config, v string) error
+}
+
+func settings() []setting {
+	return []setting{
+		{"SERVER_ADDR", "addr", "address the HTTP server listens on", func(c *Config, v string) error {
+			c.Server.Addr = v
+			return nil
+		}},
+		{"SERVER_REQUEST_TIMEOUT", "request-timeout", "deadline for a single request", durationSetter(func(c *Config) *time.Duration { return &c.Server.RequestTimeout })},
+		{"SERVER_SHUTDOWN_TIMEOUT", "shutdown-timeout", "grace period for in-flight requests on shutdown", durationSetter(func(c *Config) *time.Duration { return &c.Server.ShutdownTimeout })},
+		{"DATABASE_URL", "database-url", "postgres connection string", func(c *Config, v string) error {
+			c.Database.URL = Secret(v)
+			return nil
+		}},
+		{"DATABASE_LOG_LEVEL", "database-log-level", "gorm log level: silent, error, warn or info", func(c *Config, v string) error {
+			c.Database.LogLevel = strings.ToLower(v)
+			return nil
+		}},
+		{"DATABASE_SLOW_THRESHOLD", "database-slow-threshold", "queries slower than this are logged", durationSetter(func(c *Config) *time.Duration { return &c.Database.SlowThreshold })},
+		{"JWT_ISSUER", "jwt-issuer", "iss claim of issued tokens", func(c *Config, v string) error {
+			c.JWT.Issuer = v
+			return nil
+		}},
+		{"JWT_ALGORITHM", "jwt-algorithm", "access token signing algorithm: HS256, RS256 or EdDSA", func(c *Config, v string) error {
+			c.JWT.Algorithm = v
+			return nil
+		}},
+		{"JWT_SECRET", "jwt-secret", "HMAC key used to sign access tokens with HS256", func(c *Config, v string) error {
+			c.JWT.Secret = Secret(v)
+			return nil
+		}},
+		{"JWT_KEY_DIR", "jwt-key-dir", "directory holding the RS256/EdDSA private keys as <kid>.pem", func(c *Config, v string) error {
+			c.JWT.KeyDir = v
+			return nil
+		}},
+		{"JWT_ROTATION_PERIOD", "jwt-rotation-period", "how often a new RS256/EdDSA signing key is generated, 0 to disable", durationSetter(func(c *Config) *time.Duration { return &c.JWT.RotationPeriod })},
+		{"JWT_ACCESS_TOKEN_TTL", "jwt-access-token-ttl", "lifetime of an access token", durationSetter(func(c *Config) *time.Duration { return &c.JWT.AccessTokenTTL })},
+		{"JWT_REFRESH_TOKEN_TTL", "jwt-refresh-token-ttl", "lifetime of a refresh token", durationSetter(func(c *Config) *time.Duration { return &c.JWT.RefreshTokenTTL })},
+		{"SCHEDULER_JOB_CLOSE_INTERVAL", "job-close-interval", "how often expired and filled jobs are closed", durationSetter(func(c *Config) *time.Duration { return &c.Scheduler.JobCloseInterval })},
+		{"SCHEDULER_PURGE_INTERVAL", "purge-interval", "how often soft deleted records past retention are purged", durationSetter(func(c *Config) *time.Duration { return &c.Scheduler.PurgeInterval })},
+		{"SCHEDULER_PURGE_RETENTION", "purge-retention", "how long soft deleted records are kept before they are purged", durationSetter(func(c *Config) *time.Duration { return &c.Scheduler.PurgeRetention })},
+		{"PASSWORD_BCRYPT_COST", "bcrypt-cost", "bcrypt cost of stored password hashes; lower hashes are upgraded on login", func(c *Config, v string) error {
+			cost, err := strconv.Atoi(v)
+			if err != nil {
+				return err
+			}
+			c.Password.BcryptCost = cost
+			return nil
+		}},
+		{"NOTIFY_DRIVER", "notify-driver", "how messages to users are delivered: log or file", func(c *Config, v string) error {
+			c.Notify.Driver = strings.ToLower(v)
+			return nil
+		}},
+		{"NOTIFY_FILE", "notify-file", "file the file driver appends messages to", func(c *Config, v string) error {
+			c.Notify.File = v
+			return nil
+		}},
+		{"NOTIFY_FROM", "notify-from", "sender address of emails", func(c *Config, v string) error {
+			c.Notify.From = v
+			return nil
+		}},
+		{"NOTIFY_SMTP_ADDR", "notify-smtp-addr", "host:port of the SMTP server used by the smtp driver", func(c *Config, v string) error {
+			c.Notify.SMTPAddr = v
+			return nil
+		}},
+		{"NOTIFY_SMTP_USERNAME", "notify-smtp-username", "SMTP username, empty to send without authentication", func(c *Config, v string) error {
+			c.Notify.SMTPUsername = v
+			return nil
+		}},
+		{"NOTIFY_SMTP_PASSWORD", "notify-smtp-password", "SMTP password", func(c *Config, v string) error {
+			c.Notify.SMTPPassword = Secret(v)
+			return nil
+		}},
+		{"EMAIL_VERIFY_URL", "email-verify-url", "URL the email verification link points to", func(c *Config, v string) error {
+			c.Email.VerifyURL = v
+			return nil
+		}},
+		{"EMAIL_VERIFY_SECRET", "email-verify-secret", "HMAC key used to sign email verification links", func(c *Config, v string) error {
+			c.Email.VerifySecret = Secret(v)
+			return nil
+		}},
+		{"EMAIL_VERIFY_TTL", "email-verify-ttl", "how long an email verification link works", durationSetter(func(c *Config) *time.Duration { return &c.Email.VerifyTTL })},
+	}
+}
+
+func durationSetter(field func(c *Config) *time.Duration) func(c *Config, v string) error {
+	return func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+// Load builds the config from args (without the program name). The env file
+// is taken from -config or CONFIG_FILE and defaults to DefaultFile, which may
+// be missing; an explicitly named file must exist.
+func Load(args []string) (Config, error) {
+	fs := flag.NewFlagSet("job-portal", flag.ContinueOnError)
+	file := fs.String("config", "", "env file to read settings from")
+	for _, s := range settings() {
+		fs.String(s.flag, "", s.usage+" ($"+s.env+")")
+	}
+	if err := fs.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	path, explicit := *file, *file != ""
+	if !explicit {
+		path, explicit = os.LookupEnv("CONFIG_FILE")
+	}
+	if path == "" {
+		path = DefaultFile
+	}
+
+	values, err := godotenv.Read(path)
+	if err != nil {
+		if explicit || !errors.Is(err, os.ErrNotExist) {
+			return Config{}, fmt.Errorf("reading config file %s: %w", path, err)
+		}
+		values = map[string]string{}
+	}
+
+	for _, s := range settings() {
+		if v, ok := os.LookupEnv(s.env); ok {
+			values[s.env] = v
+		}
+	}
+
+	fs.Visit(func(f *flag.Flag) {
+		for _, s := range settings() {
+			if s.flag == f.Name {
+				values[s.env] = f.Value.String()
+			}
+		}
+	})
+
+	cfg := Default()
+	var invalid ValidationError
+	for _, s := range settings() {
+		v, ok := values[s.env]
+		if !ok {
+			continue
+		}
+		if err := s.set(&cfg, v); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", s.env, err))
+		}
+	}
+	if len(invalid) > 0 {
+		return Config{}, invalid
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+const (
+	minJWTSecretLength         = 32
+	minEmailVerifySecretLength = 32
+)
+
+func (c Config) Validate() error {
+	var invalid ValidationError
+
+	if c.Server.Addr == "" {
+		invalid = append(invalid, "SERVER_ADDR is required")
+	}
+	if c.Server.RequestTimeout <= 0 {
+		invalid = append(invalid, "SERVER_REQUEST_TIMEOUT must be positive")
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		invalid = append(invalid, "SERVER_SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.Database.URL == "" {
+		invalid = append(invalid, "DATABASE_URL is required")
+	}
+	switch c.Database.LogLevel {
+	case "silent", "error", "warn", "info":
+	default:
+		invalid = append(invalid, "DATABASE_LOG_LEVEL must be one of silent, error, warn, info")
+	}
+	if c.JWT.Issuer == "" {
+		invalid = append(invalid, "JWT_ISSUER is required")
+	}
+	switch c.JWT.Algorithm {
+	case JWTAlgorithmHS256:
+		if len(c.JWT.Secret) < minJWTSecretLength {
+			invalid = append(invalid, fmt.Sprintf("JWT_SECRET must be at least %d bytes", minJWTSecretLength))
+		}
+		if c.JWT.RotationPeriod != 0 {
+			invalid = append(invalid, "JWT_ROTATION_PERIOD requires JWT_ALGORITHM RS256 or EdDSA")
+		}
+	case JWTAlgorithmRS256, JWTAlgorithmEdDSA:
+		if c.JWT.RotationPeriod < 0 {
+			invalid = append(invalid, "JWT_ROTATION_PERIOD must not be negative")
+		}
+	default:
+		invalid = append(invalid, "JWT_ALGORITHM must be one of HS256, RS256, EdDSA")
+	}
+	if c.JWT.AccessTokenTTL <= 0 {
+		invalid = append(invalid, "JWT_ACCESS_TOKEN_TTL must be positive")
+	}
+	if c.JWT.RefreshTokenTTL <= c.JWT.AccessTokenTTL {
+		invalid = append(invalid, "JWT_REFRESH_TOKEN_TTL must be longer than JWT_ACCESS_TOKEN_TTL")
+	}
+	if c.Scheduler.JobCloseInterval <= 0 {
+		invalid = append(invalid, "SCHEDULER_JOB_CLOSE_INTERVAL must be positive")
+	}
+	if c.Scheduler.PurgeInterval <= 0 {
+		invalid = append(invalid, "SCHEDULER_PURGE_INTERVAL must be positive")
+	}
+	if c.Scheduler.PurgeRetention <= 0 {
+		invalid = append(invalid, "SCHEDULER_PURGE_RETENTION must be positive")
+	}
+	if c.Password.BcryptCost < MinBcryptCost || c.Password.BcryptCost > MaxBcryptCost {
+		invalid = append(invalid, fmt.Sprintf("PASSWORD_BCRYPT_COST must be between %d and %d", MinBcryptCost, MaxBcryptCost))
+	}
+	switch c.Notify.Driver {
+	case NotifyDriverLog:
+	case NotifyDriverFile:
+		if c.Notify.File == "" {
+			invalid = append(invalid, "NOTIFY_FILE is required with NOTIFY_DRIVER file")
+		}
+	case NotifyDriverSMTP:
+		if c.Notify.SMTPAddr == "" {
+			invalid = append(invalid, "NOTIFY_SMTP_ADDR is required with NOTIFY_DRIVER smtp")
+		}
+		if c.Notify.From == "" {
+			invalid = append(invalid, "NOTIFY_FROM is required with NOTIFY_DRIVER smtp")
+		}
+	default:
+		invalid = append(invalid, "NOTIFY_DRIVER must be one of log, file, smtp")
+	}
+	if c.Email.VerifyURL == "" {
+		invalid = append(invalid, "EMAIL_VERIFY_URL is required")
+	}
+	if len(c.Email.VerifySecret) < minEmailVerifySecretLength {
+		invalid = append(invalid, fmt.Sprintf("EMAIL_VERIFY_SECRET must be at least %d bytes", minEmailVerifySecretLength))
+	}
+	if c.Email.VerifyTTL <= 0 {
+		invalid = append(invalid, "EMAIL_VERIFY_TTL must be positive")
+	}
+
+	if len(invalid) > 0 {
+		return invalid
+	}
+	return nil
+}
@@ -3,25 +3,23 @@ package db
 import (
 	"log"
 	"os"
-	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/adityatresnobudi/job-portal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
-func Connect() (*gorm.DB, error) {
-	err := godotenv.Load(".env")
-	if err != nil {
-		log.Fatalf("Error loading .env file")
-	}
-
-	dsn := os.Getenv("DATABASE_URL")
-	log.Println(dsn)
+var logLevels = map[string]logger.LogLevel{
+	"silent": logger.Silent,
+	"error":  logger.Error,
+	"warn":   logger.Warn,
+	"info":   logger.Info,
+}
 
-	newLogger := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{SlowThreshold: time.Second, LogLevel: logger.Info, Colorful: true})
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: newLogger, TranslateError: true})
+func Connect(cfg config.Database) (*gorm.DB, error) {
+	newLogger := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{SlowThreshold: cfg.SlowThreshold, LogLevel: logLevels[cfg.LogLevel], Colorful: true})
+	db, err := gorm.Open(postgres.Open(cfg.URL.Value()), &gorm.Config{Logger: newLogger, TranslateError: true})
 	if err != nil {
 		return nil, err
 	}
@@ -1,5 +1,10 @@
 package dto
 
+import "time"
+
+// DateTimeLayout is how dates and times are written in requests and responses.
+const DateTimeLayout = "2006-01-02 15:04:05"
+
 type JobsDTO struct {
 	ID          uint   `json:"id"`
 	JobPosterId uint   `json:"job_poster_id"`
@@ -9,29 +14,143 @@ type JobsDTO struct {
 }
 
 type JobsPayload struct {
-	ID          uint   `json:"id"`
-	JobPosterId uint   `json:"job_poster_id" binding:"required"`
-	JobName     string `json:"job_name" binding:"required"`
-	JobDesc     string `json:"job_desc" binding:"required"`
-	Quota       int    `json:"quota" binding:"required"`
-	ExpiryDate  string `json:"expiry_date" binding:"required"`
+	ID             uint   `json:"id"`
+	OrganizationId uint   `json:"organization_id" binding:"required"`
+	JobPosterId    uint   `json:"job_poster_id"`
+	JobName        string `json:"job_name" binding:"required,max=255"`
+	JobDesc        string `json:"job_desc" binding:"required,max=5000"`
+	Quota          int    `json:"quota" binding:"min=1"`
+	ExpiryDate     string `json:"expiry_date" binding:"required,datetime=2006-01-02 15:04:05,future"`
+	Draft          bool   `json:"draft"`
 }
 
 type JobsResponse struct {
-	ID          uint   `json:"id"`
-	JobPosterId uint   `json:"job_poster_id"`
-	JobName     string `json:"job_name"`
-	JobDesc     string `json:"job_desc"`
-	Quota       int    `json:"quota"`
-	ExpiryDate  string `json:"expiry_date"`
+	ID             uint   `json:"id"`
+	OrganizationId uint   `json:"organization_id"`
+	JobPosterId    uint   `json:"job_poster_id"`
+	JobName        string `json:"job_name"`
+	JobDesc        string `json:"job_desc"`
+	Quota          int    `json:"quota"`
+	ExpiryDate     string `json:"expiry_date"`
+	Version        int    `json:"version"`
 }
 
 type CloseJobsResponse struct {
+	ID             uint   `json:"id"`
+	OrganizationId uint   `json:"organization_id"`
+	JobPosterId    uint   `json:"job_poster_id"`
+	JobName        string `json:"job_name"`
+	JobDesc        string `json:"job_desc"`
+	Quota          int    `json:"quota"`
+	Status         string `json:"status"`
+	ExpiryDate     string `json:"expiry_date"`
+	Version        int    `json:"version"`
+}
+
+// JobPatch is a JSON Merge Patch (RFC 7396) of a job. Members left out of
+// the patch are nil and keep their value.
+type JobPatch struct {
+	JobName    *string `json:"job_name" binding:"omitempty,min=1,max=255"`
+	JobDesc    *string `json:"job_desc" binding:"omitempty,min=1,max=5000"`
+	Quota      *int    `json:"quota" binding:"omitempty,min=1"`
+	ExpiryDate *string `json:"expiry_date" binding:"omitempty,datetime=2006-01-02 15:04:05,future"`
+}
+
+const (
+	SortJobsNewest   = "newest"
+	SortJobsExpiring = "expiring"
+	SortJobsQuota    = "quota"
+)
+
+type JobsQuery struct {
+	PaginationQuery
+	Name           string    `form:"name"`
+	JobPosterId    uint      `form:"job_poster_id"`
+	OrganizationId uint      `form:"organization_id"`
+	MinQuota       int       `form:"min_quota" binding:"omitempty,min=0"`
+	ExpiresAfter   time.Time `form:"expires_after" time_format:"2006-01-02"`
+	ExpiresBefore  time.Time `form:"expires_before" time_format:"2006-01-02"`
+	CreatedAfter   time.Time `form:"created_after" time_format:"2006-01-02"`
+	Sort           string    `form:"sort" binding:"omitempty,oneof=newest expiring quota"`
+}
+
+type JobDetailResponse struct {
+	ID         uint         `json:"id"`
+	JobName    string       `json:"job_name"`
+	JobDesc    string       `json:"job_desc"`
+	Quota      int          `json:"quota"`
+	Status     string       `json:"status"`
+	ExpiryDate string       `json:"expiry_date"`
+	PostedAt   string       `json:"posted_at"`
+	Version    int          `json:"version"`
+	JobPoster  JobPosterDTO `json:"job_poster"`
+}
+
+type JobPosterDTO struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"user_name"`
+	CurrentJob string `json:"current_job,omitempty"`
+}
+
+type JobStatusHistoryDTO struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+	ChangedBy  uint   `json:"changed_by"`
+	Reason     string `json:"reason,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+type JobSearchQuery struct {
+	PaginationQuery
+	Q string `form:"q" binding:"required"`
+}
+
+type JobSearchResult struct {
+	JobsDTO
+	Rank       float64          `json:"rank"`
+	Highlights JobsHighlightDTO `json:"highlights"`
+}
+
+// JobsHighlightDTO holds HTML: the job's text escaped, with the matched words
+// wrapped in <mark>.
+type JobsHighlightDTO struct {
+	JobName string `json:"job_name"`
+	JobDesc string `json:"job_desc"`
+}
+
+type DeletedJobDTO struct {
 	ID          uint   `json:"id"`
 	JobPosterId uint   `json:"job_poster_id"`
 	JobName     string `json:"job_name"`
-	JobDesc     string `json:"job_desc"`
-	Quota       int    `json:"quota"`
-	IsOpen      bool   `json:"is_open"`
+	Status      string `json:"status"`
 	ExpiryDate  string `json:"expiry_date"`
+	DeletedAt   string `json:"deleted_at,omitempty"`
+}
+
+type JobRevisionDTO struct {
+	Version   int              `json:"version"`
+	ChangedBy uint             `json:"changed_by"`
+	Reason    string           `json:"reason"`
+	ChangedAt string           `json:"changed_at"`
+	Changes   []JobFieldChange `json:"changes"`
+}
+
+// JobFieldChange is one field that differs between two revisions of a job.
+// Before is null for the revision a job was created with.
+type JobFieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+type JobRevisionDiffQuery struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+type JobRevisionDiffDTO struct {
+	JobId   uint             `json:"job_id"`
+	From    int              `json:"from"`
+	To      int              `json:"to"`
+	Changes []JobFieldChange `json:"changes"`
 }
@@ -0,0 +1,36 @@
+package dto
+
+type OrganizationPayload struct {
+	Name string `json:"name" binding:"required,max=255"`
+}
+
+type OrganizationResponse struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"name"`
+	Role      string `json:"role,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type OrganizationDetailResponse struct {
+	ID        uint                    `json:"id"`
+	Name      string                  `json:"name"`
+	CreatedBy *uint                   `json:"created_by"`
+	CreatedAt string                  `json:"created_at"`
+	Members   []OrganizationMemberDTO `json:"members"`
+}
+
+type OrganizationMemberDTO struct {
+	UserId   uint   `json:"user_id"`
+	Name     string `json:"user_name,omitempty"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+type OrganizationMemberPayload struct {
+	UserId uint   `json:"user_id" binding:"required"`
+	Role   string `json:"role" binding:"required,oneof=owner admin recruiter"`
+}
+
+type OrganizationRolePayload struct {
+	Role string `json:"role" binding:"required,oneof=owner admin recruiter"`
+}
@@ -0,0 +1,51 @@
+package dto
+
+const (
+	DefaultPage  = 1
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+type PaginationQuery struct {
+	Page  int `form:"page" binding:"omitempty,min=1"`
+	Limit int `form:"limit" binding:"omitempty,min=1"`
+}
+
+type PaginationResponse struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalItems int64  `json:"total_items"`
+	TotalPages int    `json:"total_pages"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+}
+
+func (p *PaginationQuery) Normalize() {
+	if p.Page < 1 {
+		p.Page = DefaultPage
+	}
+	if p.Limit < 1 {
+		p.Limit = DefaultLimit
+	}
+	if p.Limit > MaxLimit {
+		p.Limit = MaxLimit
+	}
+}
+
+func (p PaginationQuery) Offset() int {
+	return (p.Page - 1) * p.Limit
+}
+
+func NewPaginationResponse(p PaginationQuery, totalItems int64) PaginationResponse {
+	totalPages := int(totalItems) / p.Limit
+	if int(totalItems)%p.Limit != 0 {
+		totalPages++
+	}
+
+	return PaginationResponse{
+		Page:       p.Page,
+		Limit:      p.Limit,
+		TotalItems: totalItems,
+		TotalPages: totalPages,
+	}
+}
@@ -1,6 +1,7 @@
 package dto
 
 type JsonResponse struct {
-	Data    any    `json:"data,omitempty"`
-	Message string `json:"message,omitempty"`
+	Data       any                 `json:"data,omitempty"`
+	Message    string              `json:"message,omitempty"`
+	Pagination *PaginationResponse `json:"pagination,omitempty"`
 }
@@ -2,30 +2,92 @@ package dto
 
 type UserPayload struct {
 	ID          uint   `json:"id"`
-	Name        string `json:"user_name" binding:"required"`
-	Email       string `json:"email" binding:"required"`
-	Phone       string `json:"phone" binding:"required"`
-	Password    string `json:"user_password" binding:"required"`
-	CurrentJob  string `json:"current_job"`
-	Age         uint   `json:"user_age"`
-	IsJobPoster bool   `json:"is_job_poster" binding:"required"`
+	Name        string `json:"user_name" binding:"required,max=255"`
+	Email       string `json:"email" binding:"required,email,max=255"`
+	Phone       string `json:"phone" binding:"required,phone"`
+	Password    string `json:"user_password" binding:"required,min=8,max=72"`
+	CurrentJob  string `json:"current_job" binding:"max=255"`
+	Age         uint   `json:"user_age" binding:"omitempty,min=16,max=100"`
+	IsJobPoster bool   `json:"is_job_poster"`
 }
 
 type UserResponse struct {
-	ID          uint   `json:"id"`
-	Name        string `json:"user_name"`
-	Email       string `json:"email"`
-	Phone       string `json:"phone"`
-	CurrentJob  string `json:"current_job,omitempty"`
-	Age         uint   `json:"user_age,omitempty"`
-	IsJobPoster bool   `json:"is_job_poster"`
+	ID            uint   `json:"id"`
+	Name          string `json:"user_name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Phone         string `json:"phone"`
+	CurrentJob    string `json:"current_job,omitempty"`
+	Age           uint   `json:"user_age,omitempty"`
+	IsJobPoster   bool   `json:"is_job_poster"`
 }
 
 type LoginRequest struct {
-	Email string `json:"email" binding:"required"`
+	Email    string `json:"email" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
 
 type LoginResponse struct {
-	AccessToken string `json:"access_token"`
-}
\ No newline at end of file
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type DeletedUserDTO struct {
+	ID        uint   `json:"id"`
+	Name      string `json:"user_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+}
+
+// UpdateProfileRequest is a partial update; a field left out of the body is
+// kept as it is.
+type UpdateProfileRequest struct {
+	Name       *string `json:"user_name" binding:"omitempty,min=1,max=255"`
+	Email      *string `json:"email" binding:"omitempty,email,max=255"`
+	Phone      *string `json:"phone" binding:"omitempty,phone"`
+	CurrentJob *string `json:"current_job" binding:"omitempty,max=255"`
+	Age        *uint   `json:"user_age" binding:"omitempty,min=16,max=100"`
+}
+
+type UpdateProfileResponse struct {
+	UserResponse
+	PendingEmail string `json:"pending_email,omitempty"`
+}
+
+type ConfirmEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// PublicProfileResponse is what recruiters see of another user; contact
+// details stay private.
+type PublicProfileResponse struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"user_name"`
+	CurrentJob string `json:"current_job,omitempty"`
+	Age        uint   `json:"user_age,omitempty"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8,max=72"`
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8,max=72"`
+}
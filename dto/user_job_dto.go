@@ -1,13 +1,84 @@
 package dto
 
 type UserJobsDTO struct {
-	JobId uint `json:"job_id"`
-	Status string `json:"status"`
-	Message string `json:"message"`
+	JobId     uint   `json:"job_id"`
+	Status    string `json:"status"`
 	AppliedAt string `json:"applied_at"`
 }
 
 type UserJobsPayload struct {
 	UserId uint `json:"user_id" binding:"required"`
-	JobId uint `json:"job_id" binding:"required"`
-}
\ No newline at end of file
+	JobId  uint `json:"job_id" binding:"required"`
+}
+
+type ApplicationsQuery struct {
+	PaginationQuery
+	Status string `form:"status" binding:"omitempty,oneof=applied screening interview offered hired rejected withdrawn"`
+}
+
+type ApplicationResponse struct {
+	ID        uint              `json:"id"`
+	Status    string            `json:"status"`
+	AppliedAt string            `json:"applied_at"`
+	UpdatedAt string            `json:"updated_at"`
+	Job       ApplicationJobDTO `json:"job"`
+}
+
+type ApplicationJobDTO struct {
+	ID          uint   `json:"id"`
+	JobPosterId uint   `json:"job_poster_id"`
+	JobName     string `json:"job_name"`
+	JobDesc     string `json:"job_desc"`
+	Status      string `json:"status"`
+	ExpiryDate  string `json:"expiry_date"`
+}
+
+type ApplicationStatusPayload struct {
+	Status string `json:"status" binding:"required,oneof=screening interview offered hired rejected"`
+	Note   string `json:"note"`
+}
+
+type ApplicationStatusHistoryDTO struct {
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status"`
+	ChangedBy  uint   `json:"changed_by"`
+	Note       string `json:"note,omitempty"`
+	ChangedAt  string `json:"changed_at"`
+}
+
+const (
+	SortApplicantsNewest = "newest"
+	SortApplicantsOldest = "oldest"
+)
+
+type ApplicantsQuery struct {
+	PaginationQuery
+	Status string `form:"status" binding:"omitempty,oneof=applied screening interview offered hired rejected withdrawn"`
+	Sort   string `form:"sort" binding:"omitempty,oneof=newest oldest"`
+}
+
+type ApplicantResponse struct {
+	ApplicationId uint                `json:"application_id"`
+	Status        string              `json:"status"`
+	AppliedAt     string              `json:"applied_at"`
+	UpdatedAt     string              `json:"updated_at"`
+	Applicant     ApplicantProfileDTO `json:"applicant"`
+}
+
+type ApplicantProfileDTO struct {
+	ID         uint   `json:"id"`
+	Name       string `json:"user_name"`
+	Email      string `json:"email"`
+	Phone      string `json:"phone"`
+	CurrentJob string `json:"current_job,omitempty"`
+	Age        uint   `json:"user_age,omitempty"`
+}
+
+type DeletedApplicationDTO struct {
+	ID        uint   `json:"id"`
+	JobId     uint   `json:"job_id"`
+	UserId    uint   `json:"user_id"`
+	Status    string `json:"status"`
+	AppliedAt string `json:"applied_at"`
+	DeletedAt string `json:"deleted_at,omitempty"`
+}
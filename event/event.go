@@ -0,0 +1,47 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/logger"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	JobClosed = "job.closed"
+)
+
+type Event struct {
+	Type       string
+	Data       map[string]interface{}
+	OccurredAt time.Time
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, e Event) error
+}
+
+type logPublisher struct {
+	log logger.Logger
+}
+
+// NewLogPublisher returns a Publisher that writes every event to the log.
+// It stands in until the service has a real message broker.
+func NewLogPublisher(log logger.Logger) Publisher {
+	return &logPublisher{
+		log: log,
+	}
+}
+
+func (p *logPublisher) Publish(ctx context.Context, e Event) error {
+	fields := logrus.Fields{
+		"event":       e.Type,
+		"occurred_at": e.OccurredAt,
+	}
+	for k, v := range e.Data {
+		fields[k] = v
+	}
+	p.log.Info(fields)
+	return nil
+}
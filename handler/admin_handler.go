@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) DeleteUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	if err := h.UserUsecase.DeleteUser(ctx, uint(id)); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "user.deleted", id)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg})
+}
+
+func (h *Handler) GetDeletedUsers(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := dto.PaginationQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	users, pagination, err := h.UserUsecase.GetDeletedUsers(ctx, query)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: users, Pagination: &pagination})
+}
+
+func (h *Handler) RestoreUser(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	user, err := h.UserUsecase.RestoreUser(ctx, uint(id))
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "user.restored", user.ID)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: user})
+}
+
+func (h *Handler) GetDeletedJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := dto.PaginationQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	jobs, pagination, err := h.JobUsecase.GetDeletedJobs(ctx, query)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: jobs, Pagination: &pagination})
+}
+
+func (h *Handler) RestoreJob(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	job, err := h.JobUsecase.RestoreJob(ctx, jobId)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "job.restored", job.ID)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: job})
+}
+
+func (h *Handler) GetDeletedApplications(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := dto.PaginationQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	applications, pagination, err := h.UserJobUsecase.GetDeletedApplications(ctx, query)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: applications, Pagination: &pagination})
+}
+
+func (h *Handler) RestoreApplication(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	application, err := h.UserJobUsecase.RestoreApplication(ctx, id, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "application.restored", application.ID)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: application})
+}
@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+)
+
+// versionETag is the strong ETag of a resource at version.
+func versionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ifMatchVersions lists the versions an If-Match header accepts, or nil for
+// "*". Weak and malformed tags never match, so a header made only of them
+// accepts no version at all.
+func ifMatchVersions(header string) []int {
+	versions := []int{}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.Atoi(tag[1 : len(tag)-1])
+		if err != nil {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions
+}
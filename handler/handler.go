@@ -6,12 +6,14 @@ type Handler struct {
 	JobUsecase usecase.JobUsecase
 	UserUsecase usecase.UserUsecase
 	UserJobUsecase usecase.UserJobUsecase
+	OrganizationUsecase usecase.OrganizationUsecase
 }
 
-func NewHandler(JobUsecase usecase.JobUsecase, UserUsecase usecase.UserUsecase, UserJobUsecase usecase.UserJobUsecase) *Handler {
+func NewHandler(JobUsecase usecase.JobUsecase, UserUsecase usecase.UserUsecase, UserJobUsecase usecase.UserJobUsecase, OrganizationUsecase usecase.OrganizationUsecase) *Handler {
 	return &Handler{
 		JobUsecase: JobUsecase,
 		UserUsecase: UserUsecase,
 		UserJobUsecase: UserJobUsecase,
+		OrganizationUsecase: OrganizationUsecase,
 	}
 }
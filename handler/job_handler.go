@@ -9,47 +9,149 @@ import (
 
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 )
 
+// mergePatchContentType is the media type of JSON Merge Patch (RFC 7396).
+const mergePatchContentType = "application/merge-patch+json"
+
 func (h *Handler) GetJobs(c *gin.Context) {
 	ctx := c.Request.Context()
-	jobName := c.Query("name")
-	jobs, err := h.JobUsecase.GetAvailableJobs(ctx, jobName)
+	query := dto.JobsQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	jobs, pagination, err := h.JobUsecase.GetAvailableJobs(ctx, query)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, dto.JsonResponse{Data: jobs})
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: jobs, Pagination: &pagination})
+}
+
+func (h *Handler) SearchJobs(c *gin.Context) {
+	ctx := c.Request.Context()
+	query := dto.JobSearchQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	results, pagination, err := h.JobUsecase.SearchJobs(ctx, query)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: results, Pagination: &pagination})
+}
+
+func (h *Handler) GetJobDetail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	job, err := h.JobUsecase.GetJobDetail(ctx, jobId)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.Header("ETag", versionETag(job.Version))
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: job})
 }
 
 func (h *Handler) CreateNewJobs(c *gin.Context) {
 	ctx := c.Request.Context()
-	jobPosterId := c.GetUint("id")
+	principal := currentPrincipal(c)
 	newJob := dto.JobsPayload{}
 	if err := c.ShouldBindJSON(&newJob); err != nil {
 		log.Println(err)
-		c.Error(shared.ErrInvalidRequestBody)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
 		return
 	}
 
 	newJob.JobName = strings.TrimSpace(newJob.JobName)
 
-	jobs, err := h.JobUsecase.CreateJobs(ctx, newJob, jobPosterId)
+	jobs, err := h.JobUsecase.CreateJobs(ctx, newJob, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "job.created", jobs.ID)
+	c.Header("ETag", versionETag(jobs.Version))
+	c.JSON(http.StatusCreated, dto.JsonResponse{Message: msg, Data: jobs})
+}
+
+func (h *Handler) ChangeJobStatus(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		principal := currentPrincipal(c)
+
+		jobId, err := strconv.Atoi(c.Param("id"))
+		if err != nil {
+			log.Println(err)
+			c.Error(shared.ErrIdNotFound)
+			return
+		}
+
+		output, err := h.JobUsecase.ChangeJobStatus(ctx, jobId, action, principal)
+		if err != nil {
+			log.Println(err)
+			c.Error(err)
+			return
+		}
+		msg := message(c, "job.status."+output.Status, output.ID)
+		c.Header("ETag", versionETag(output.Version))
+		c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: output})
+	}
+}
+
+func (h *Handler) GetJobStatusHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	histories, err := h.JobUsecase.GetJobStatusHistory(ctx, jobId, principal)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
-	message := fmt.Sprintf("successfully add post job with id %d", jobs.ID)
-	c.JSON(http.StatusCreated, dto.JsonResponse{Message: message, Data: jobs})
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: histories})
 }
 
-func (h *Handler) CloseJobs(c *gin.Context) {
+// PatchJob edits a job with a JSON Merge Patch. The ETag of the job must be
+// sent in If-Match, so an edit based on a stale copy fails with 412 instead
+// of overwriting a newer change.
+func (h *Handler) PatchJob(c *gin.Context) {
 	ctx := c.Request.Context()
-	jobPosterId := c.GetUint("id")
+	principal := currentPrincipal(c)
 
 	jobId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -58,29 +160,49 @@ func (h *Handler) CloseJobs(c *gin.Context) {
 		return
 	}
 
-	cjUpdate, err := h.JobUsecase.GetJobsByID(ctx, jobId)
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.Error(shared.ErrJobPreconditionRequired)
+		return
+	}
+
+	if contentType := c.ContentType(); contentType != mergePatchContentType && contentType != binding.MIMEJSON {
+		c.Error(shared.ErrUnsupportedMediaType)
+		return
+	}
+
+	body, err := c.GetRawData()
 	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrInvalidRequestBody)
+		return
+	}
+
+	patch := dto.JobPatch{}
+	if err := validation.DecodeMergePatch(body, &patch); err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
 
-	output, err := h.JobUsecase.CloseJob(ctx, cjUpdate, jobPosterId)
+	output, err := h.JobUsecase.PatchJob(ctx, jobId, patch, ifMatchVersions(ifMatch), principal)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
-	message := fmt.Sprintf("successfully closed job with id %d", output.ID)
-	c.JSON(http.StatusOK, dto.JsonResponse{Message: message, Data: output})
+
+	c.Header("ETag", versionETag(output.Version))
+	msg := message(c, "job.changed", jobId)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: output})
 }
 
+// ChangeJobs edits quota and expiry from query parameters.
+//
+// Deprecated: use PatchJob. If-Match is honoured when sent but not required.
 func (h *Handler) ChangeJobs(c *gin.Context) {
 	ctx := c.Request.Context()
-	output := dto.CloseJobsResponse{}
-	jobPosterId := c.GetUint("id")
-	quota := c.Query("quota")
-	expDate := c.Query("expDate")
+	principal := currentPrincipal(c)
 
 	jobId, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
@@ -89,40 +211,117 @@ func (h *Handler) ChangeJobs(c *gin.Context) {
 		return
 	}
 
-	q, err := strconv.Atoi(quota)
+	patch := dto.JobPatch{}
+	if quota := c.Query("quota"); quota != "" {
+		q, err := strconv.Atoi(quota)
+		if err != nil {
+			log.Println(err)
+			c.Error(shared.NewValidationError(validation.NewFieldError("quota", validation.CodeInvalidType, "number")))
+			return
+		}
+		patch.Quota = &q
+	}
+	if expDate := c.Query("expDate"); expDate != "" {
+		patch.ExpiryDate = &expDate
+	}
+	if err := binding.Validator.ValidateStruct(&patch); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	var versions []int
+	if ifMatch := c.GetHeader("If-Match"); ifMatch != "" {
+		versions = ifMatchVersions(ifMatch)
+	}
+
+	output, err := h.JobUsecase.PatchJob(ctx, jobId, patch, versions, principal)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
 
-	cjUpdate, err := h.JobUsecase.GetJobsByID(ctx, jobId)
+	c.Header("Deprecation", "true")
+	c.Header("Link", fmt.Sprintf(`</jobs/%d>; rel="successor-version"`, jobId))
+	c.Header("ETag", versionETag(output.Version))
+	msg := message(c, "job.changed", jobId)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: output})
+}
+
+func (h *Handler) GetJobRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	query := dto.PaginationQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	revisions, pagination, err := h.JobUsecase.GetJobRevisions(ctx, jobId, query, principal)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
 
-	if quota != "" {
-		res, err := h.JobUsecase.UpdateQuota(ctx, cjUpdate, q, jobPosterId)
-		if err != nil {
-			log.Println(err)
-			c.Error(err)
-			return
-		}
-		output = res
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: revisions, Pagination: &pagination})
+}
+
+func (h *Handler) DiffJobRevisions(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
 	}
 
-	if expDate != "" {
-		res, err := h.JobUsecase.UpdateExpDate(ctx, cjUpdate, expDate, jobPosterId)
-		if err != nil {
-			log.Println(err)
-			c.Error(err)
-			return
-		}
-		output = res
+	query := dto.JobRevisionDiffQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
 	}
 
-	message := fmt.Sprintf("successfully change job with id %d", jobId)
-	c.JSON(http.StatusNoContent, dto.JsonResponse{Message: message, Data: output})
+	diff, err := h.JobUsecase.DiffJobRevisions(ctx, jobId, query, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: diff})
+}
+
+func (h *Handler) DeleteJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	if err := h.JobUsecase.DeleteJob(ctx, jobId, principal); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "job.deleted", jobId)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg})
 }
@@ -7,11 +7,15 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/handler"
 	"github.com/adityatresnobudi/job-portal/mocks"
 	"github.com/adityatresnobudi/job-portal/router"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/usecase"
+	"github.com/adityatresnobudi/job-portal/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -29,12 +33,13 @@ func createJobsDTO() dto.JobsDTO {
 
 func createJobsPayload() dto.JobsPayload {
 	return dto.JobsPayload{
-		ID:          1,
-		JobPosterId: 2,
-		JobName:     "test",
-		JobDesc:     "test",
-		Quota:       3,
-		ExpiryDate:  "2023-06-14 20:00:00",
+		ID:             1,
+		OrganizationId: 5,
+		JobPosterId:    2,
+		JobName:        "test",
+		JobDesc:        "test",
+		Quota:          3,
+		ExpiryDate:     "2099-06-14 20:00:00",
 	}
 }
 
@@ -46,6 +51,7 @@ func createJobsResponse() dto.JobsResponse {
 		JobDesc:     "test",
 		Quota:       3,
 		ExpiryDate:  "2023-06-14 20:00:00",
+		Version:     1,
 	}
 }
 
@@ -56,11 +62,15 @@ func createCloseJobsResponse() dto.CloseJobsResponse {
 		JobName:     "test",
 		JobDesc:     "test",
 		Quota:       3,
-		IsOpen:      true,
+		Status:      "published",
 		ExpiryDate:  "2023-06-14 20:00:00",
 	}
 }
 
+func createPrincipal() auth.Principal {
+	return auth.NewPrincipal(1, auth.RolePoster)
+}
+
 func MakeRequestBody(dto interface{}) *strings.Reader {
 	payload, _ := json.Marshal(dto)
 	return strings.NewReader(string(payload))
@@ -73,7 +83,7 @@ func TestJobHandler_GetJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
 		jobs := []dto.JobsDTO{
 			createJobsDTO(),
 		}
@@ -84,8 +94,9 @@ func TestJobHandler_GetJobs(t *testing.T) {
 		req, _ := http.NewRequest("GET", "/jobs", nil)
 		c.Request = req
 
-		mockJobUsecase.On("GetAvailableJobs", c.Request.Context(), "").Return(jobs, nil)
-		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: jobs})
+		pagination := dto.PaginationResponse{Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1}
+		mockJobUsecase.On("GetAvailableJobs", c.Request.Context(), dto.JobsQuery{}).Return(jobs, pagination, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: jobs, Pagination: &pagination})
 		h.GetJobs(c)
 
 		// 3. assert
@@ -99,14 +110,15 @@ func TestJobHandler_GetJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router := router.NewRouter(h)
-		mockJobUsecase.On("GetAvailableJobs", mock.Anything, "").Return(nil, shared.ErrGettingJobs)
-		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: shared.ErrGettingJobs.Message})
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockJobUsecase.On("GetAvailableJobs", mock.Anything, dto.JobsQuery{}).Return(nil, dto.PaginationResponse{}, shared.ErrGettingJobs)
+		expectedResp, _ := json.Marshal(shared.ErrGettingJobs.ToProblem("request-1"))
 
 		// 2. make request
 		rec := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/jobs", nil)
+		req.Header.Set("X-Request-ID", "request-1")
 		router.ServeHTTP(rec, req)
 
 		// 3. assert
@@ -114,6 +126,154 @@ func TestJobHandler_GetJobs(t *testing.T) {
 		str := strings.Trim(rec.Body.String(), "\n")
 		assert.Equal(t, string(expectedResp), str)
 	})
+
+	t.Run("should return next and prev links when on a middle page", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		query := dto.JobsQuery{
+			PaginationQuery: dto.PaginationQuery{Page: 2, Limit: 1},
+			Sort:            dto.SortJobsQuota,
+		}
+		pagination := dto.PaginationResponse{Page: 2, Limit: 1, TotalItems: 3, TotalPages: 3}
+		mockJobUsecase.On("GetAvailableJobs", mock.Anything, query).Return([]dto.JobsDTO{createJobsDTO()}, pagination, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs?page=2&limit=1&sort=quota", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		resp := dto.JsonResponse{}
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "/jobs?limit=1&page=3&sort=quota", resp.Pagination.Next)
+		assert.Equal(t, "/jobs?limit=1&page=1&sort=quota", resp.Pagination.Prev)
+	})
+
+	t.Run("should return status code 400 when sort key is invalid", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs?sort=random", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockJobUsecase.AssertNotCalled(t, "GetAvailableJobs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobHandler_SearchJobs(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 200 with ranked results", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		results := []dto.JobSearchResult{
+			{
+				JobsDTO:    createJobsDTO(),
+				Rank:       0.6,
+				Highlights: dto.JobsHighlightDTO{JobName: "<mark>test</mark>", JobDesc: "<mark>test</mark>"},
+			},
+		}
+		pagination := dto.PaginationResponse{Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1}
+		query := dto.JobSearchQuery{Q: `"test"`}
+		mockJobUsecase.On("SearchJobs", mock.Anything, query).Return(results, pagination, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: results, Pagination: &pagination})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/search?q=%22test%22", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 400 when query is missing", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/search", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockJobUsecase.AssertNotCalled(t, "SearchJobs", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobHandler_GetJobDetail(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 200 with job and poster", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		detail := dto.JobDetailResponse{
+			ID:         1,
+			JobName:    "test",
+			JobDesc:    "test",
+			Quota:      3,
+			Status:     "published",
+			ExpiryDate: "2023-06-14 20:00:00",
+			PostedAt:   "2023-06-01 20:00:00",
+			JobPoster:  dto.JobPosterDTO{ID: 2, Name: "poster"},
+		}
+		mockJobUsecase.On("GetJobDetail", mock.Anything, 1).Return(detail, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: detail})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 404 when job is closed or expired", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockJobUsecase.On("GetJobDetail", mock.Anything, 1).Return(dto.JobDetailResponse{}, shared.ErrJobNotFound)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
 }
 
 func TestJobHandler_CreateNewJobs(t *testing.T) {
@@ -123,24 +283,27 @@ func TestJobHandler_CreateNewJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router.NewRouter(h, config.Default().Server, nil)
 		jobPayload := createJobsPayload()
 		jobResponse := createJobsResponse()
 
+		principal := createPrincipal()
+
 		// 2. make request
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("id", uint(1))
+		c.Set(auth.PrincipalKey, principal)
 		req, _ := http.NewRequest("POST", "/jobs", MakeRequestBody(jobPayload))
 		c.Request = req
 
-		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, uint(1)).Return(jobResponse, nil)
+		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, principal).Return(jobResponse, nil)
 		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "successfully add post job with id 1", Data: jobResponse})
 		h.CreateNewJobs(c)
 
 		// 3. assert
 		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `"1"`, w.Header().Get("ETag"))
 		str := strings.Trim(w.Body.String(), "\n")
 		assert.Equal(t, string(expectedResp), str)
 	})
@@ -150,18 +313,20 @@ func TestJobHandler_CreateNewJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router.NewRouter(h, config.Default().Server, nil)
 		jobPayload := createJobsPayload()
 
+		principal := createPrincipal()
+
 		// 2. make request
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("id", uint(1))
+		c.Set(auth.PrincipalKey, principal)
 		req, _ := http.NewRequest("POST", "/jobs", MakeRequestBody(jobPayload))
 		c.Request = req
 
-		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, uint(1)).Return(dto.JobsResponse{}, shared.ErrCreatingJobs)
+		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, principal).Return(dto.JobsResponse{}, shared.ErrCreatingJobs)
 		h.CreateNewJobs(c)
 
 		// 3. assert
@@ -173,15 +338,20 @@ func TestJobHandler_CreateNewJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router.NewRouter(h, config.Default().Server, nil)
 		jobPayload := dto.JobsPayload{
 			ID:         1,
 			JobName:    "test",
 			JobDesc:    "test",
 			Quota:      3,
-			ExpiryDate: "2023-06-14 20:00:00",
+			ExpiryDate: "2099-06-14 20:00:00",
 		}
+		expectedErr := shared.NewValidationError(shared.FieldError{
+			Field:   "organization_id",
+			Code:    validation.CodeRequired,
+			Message: "organization_id is required",
+		})
 
 		// 2. make request
 		w := httptest.NewRecorder()
@@ -191,7 +361,7 @@ func TestJobHandler_CreateNewJobs(t *testing.T) {
 		h.CreateNewJobs(c)
 
 		// 3. assert
-		assert.Equal(t, c.Errors[0].Err, shared.ErrInvalidRequestBody)
+		assert.Equal(t, expectedErr, c.Errors[0].Err)
 	})
 }
 
@@ -202,46 +372,100 @@ func TestJobHandler_CloseJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		// router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		// router.NewRouter(h, config.Default().Server, nil)
 		jobCloseResponse := createCloseJobsResponse()
+		jobCloseResponse.Status = "closed"
+		jobCloseResponse.Version = 3
+
+		principal := createPrincipal()
 
 		// 2. make request
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("id", uint(1))
+		c.Set(auth.PrincipalKey, principal)
 		c.AddParam("id", "1")
 		req, _ := http.NewRequest("POST", "/jobs/1/close", nil)
 		c.Request = req
 
-		mockJobUsecase.On("GetJobsByID", c.Request.Context(), 1).Return(jobCloseResponse, nil)
-		mockJobUsecase.On("CloseJob", c.Request.Context(), jobCloseResponse, uint(1)).Return(jobCloseResponse, nil)
+		mockJobUsecase.On("ChangeJobStatus", c.Request.Context(), 1, usecase.JobActionClose, principal).Return(jobCloseResponse, nil)
 		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "successfully closed job with id 1", Data: jobCloseResponse})
-		h.CloseJobs(c)
+		h.ChangeJobStatus(usecase.JobActionClose)(c)
 
 		// 3. assert
 		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"3"`, w.Header().Get("ETag"))
 		str := strings.Trim(w.Body.String(), "\n")
 		assert.Equal(t, string(expectedResp), str)
 	})
 
+	t.Run("should answer in indonesian when the client accepts it", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		jobPauseResponse := createCloseJobsResponse()
+		jobPauseResponse.Status = "paused"
+		mockJobUsecase.On("ChangeJobStatus", mock.Anything, 1, usecase.JobActionPause, auth.Principal{}).Return(jobPauseResponse, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "berhasil menjeda lowongan dengan id 1", Data: jobPauseResponse})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/jobs/1/pause", nil)
+		req.Header.Set("Accept-Language", "id-ID,id;q=0.9,en;q=0.8")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "id", rec.Header().Get("Content-Language"))
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 409 when transition is illegal", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockJobUsecase.On("ChangeJobStatus", mock.Anything, 1, usecase.JobActionPause, auth.Principal{}).Return(dto.CloseJobsResponse{}, shared.ErrIllegalTransition)
+		expectedResp, _ := json.Marshal(shared.ErrIllegalTransition.ToProblem("request-1"))
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/jobs/1/pause", nil)
+		req.Header.Set("X-Request-ID", "request-1")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, shared.ProblemContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
 	t.Run("should return status code 500 when job fetch failed", func(t *testing.T) {
 		// 1. setup router
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router.NewRouter(h, config.Default().Server, nil)
 		jobPayload := createJobsPayload()
 
+		principal := createPrincipal()
+
 		// 2. make request
 		w := httptest.NewRecorder()
 		c, _ := gin.CreateTestContext(w)
-		c.Set("id", uint(1))
+		c.Set(auth.PrincipalKey, principal)
 		req, _ := http.NewRequest("POST", "/jobs", MakeRequestBody(jobPayload))
 		c.Request = req
 
-		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, uint(1)).Return(dto.JobsResponse{}, shared.ErrCreatingJobs)
+		mockJobUsecase.On("CreateJobs", c.Request.Context(), jobPayload, principal).Return(dto.JobsResponse{}, shared.ErrCreatingJobs)
 		h.CreateNewJobs(c)
 
 		// 3. assert
@@ -253,15 +477,20 @@ func TestJobHandler_CloseJobs(t *testing.T) {
 		mockJobUsecase := new(mocks.JobUsecase)
 		mockUserUsecase := new(mocks.UserUsecase)
 		mockUserJobUsecase := new(mocks.UserJobUsecase)
-		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase)
-		router.NewRouter(h)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router.NewRouter(h, config.Default().Server, nil)
 		jobPayload := dto.JobsPayload{
 			ID:         1,
 			JobName:    "test",
 			JobDesc:    "test",
 			Quota:      3,
-			ExpiryDate: "2023-06-14 20:00:00",
+			ExpiryDate: "2099-06-14 20:00:00",
 		}
+		expectedErr := shared.NewValidationError(shared.FieldError{
+			Field:   "organization_id",
+			Code:    validation.CodeRequired,
+			Message: "organization_id is required",
+		})
 
 		// 2. make request
 		w := httptest.NewRecorder()
@@ -271,6 +500,190 @@ func TestJobHandler_CloseJobs(t *testing.T) {
 		h.CreateNewJobs(c)
 
 		// 3. assert
-		assert.Equal(t, c.Errors[0].Err, shared.ErrInvalidRequestBody)
+		assert.Equal(t, expectedErr, c.Errors[0].Err)
+	})
+}
+
+func TestJobHandler_PatchJob(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should apply the patch and return the new etag", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		quota := 7
+		jobResponse := createCloseJobsResponse()
+		jobResponse.Quota = quota
+		jobResponse.Version = 5
+		mockJobUsecase.On("PatchJob", mock.Anything, 1, dto.JobPatch{Quota: &quota}, []int{4}, auth.Principal{}).Return(jobResponse, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "successfully change job with id 1", Data: jobResponse})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("PATCH", "/jobs/1", strings.NewReader(`{"quota": 7}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"4"`)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `"5"`, rec.Header().Get("ETag"))
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 412 when the job changed meanwhile", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockJobUsecase.On("PatchJob", mock.Anything, 1, mock.Anything, []int{3}, auth.Principal{}).Return(dto.CloseJobsResponse{}, shared.ErrJobVersionMismatch)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("PATCH", "/jobs/1", strings.NewReader(`{"job_name": "golang"}`))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		req.Header.Set("If-Match", `"3"`)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	})
+
+	tests := []struct {
+		name        string
+		body        string
+		contentType string
+		ifMatch     string
+		expected    int
+	}{
+		{name: "should require if-match", body: `{"quota": 7}`, contentType: "application/merge-patch+json", expected: http.StatusPreconditionRequired},
+		{name: "should reject other content types", body: `quota=7`, contentType: "application/x-www-form-urlencoded", ifMatch: `"4"`, expected: http.StatusUnsupportedMediaType},
+		{name: "should reject removing a field", body: `{"job_name": null}`, contentType: "application/merge-patch+json", ifMatch: `"4"`, expected: http.StatusBadRequest},
+		{name: "should reject unknown fields", body: `{"status": "closed"}`, contentType: "application/json", ifMatch: `"4"`, expected: http.StatusBadRequest},
+		{name: "should reject a quota below one", body: `{"quota": 0}`, contentType: "application/json", ifMatch: `"4"`, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 1. setup router
+			mockJobUsecase := new(mocks.JobUsecase)
+			mockUserUsecase := new(mocks.UserUsecase)
+			mockUserJobUsecase := new(mocks.UserJobUsecase)
+			h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+			router := router.NewRouter(h, config.Default().Server, nil)
+
+			// 2. make request
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest("PATCH", "/jobs/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			router.ServeHTTP(rec, req)
+
+			// 3. assert
+			assert.Equal(t, tt.expected, rec.Code)
+			mockJobUsecase.AssertNotCalled(t, "PatchJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestJobHandler_ChangeJobs(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should change the expiry date without a quota", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		expDate := "2099-06-14 20:00:00"
+		jobResponse := createCloseJobsResponse()
+		jobResponse.ExpiryDate = expDate
+		mockJobUsecase.On("PatchJob", mock.Anything, 1, dto.JobPatch{ExpiryDate: &expDate}, []int(nil), auth.Principal{}).Return(jobResponse, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "successfully change job with id 1", Data: jobResponse})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/jobs/1/update?expDate=2099-06-14+20:00:00", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get("Deprecation"))
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+}
+
+func TestJobHandler_DiffJobRevisions(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should return the diff of two revisions", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		diff := dto.JobRevisionDiffDTO{JobId: 1, From: 1, To: 3, Changes: []dto.JobFieldChange{{Field: "quota", Before: 3, After: 5}}}
+		mockJobUsecase.On("DiffJobRevisions", mock.Anything, 1, dto.JobRevisionDiffQuery{From: 1, To: 3}, auth.Principal{}).Return(diff, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: diff})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1/revisions/diff?from=1&to=3", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 400 when the to revision is not given", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1/revisions/diff?from=1", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockJobUsecase.AssertNotCalled(t, "DiffJobRevisions", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+	t.Run("should return status code 404 when a revision does not exist", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockJobUsecase.On("DiffJobRevisions", mock.Anything, 1, dto.JobRevisionDiffQuery{From: 1, To: 9}, auth.Principal{}).Return(dto.JobRevisionDiffDTO{}, shared.ErrJobRevisionNotFound)
+		expectedResp, _ := json.Marshal(shared.ErrJobRevisionNotFound.ToProblem("request-1"))
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1/revisions/diff?from=1&to=9", nil)
+		req.Header.Set("X-Request-ID", "request-1")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
 	})
 }
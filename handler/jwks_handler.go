@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/adityatresnobudi/job-portal/helper"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS serves the public keys access tokens can be verified with. Verifiers
+// are expected to refetch on an unknown kid, so a short cache is enough.
+func JWKS(tokens *helper.JWT) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Cache-Control", "public, max-age=300")
+		c.JSON(http.StatusOK, tokens.JWKS())
+	}
+}
@@ -0,0 +1,12 @@
+package handler
+
+import (
+	"github.com/adityatresnobudi/job-portal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// message translates key into the language middleware.Language negotiated,
+// or the default language when the route is not behind it.
+func message(c *gin.Context, key string, args ...interface{}) string {
+	return i18n.Translate(c.GetString(i18n.LanguageKey), key, args...)
+}
@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) CreateOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+	payload := dto.OrganizationPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	payload.Name = strings.TrimSpace(payload.Name)
+
+	organization, err := h.OrganizationUsecase.CreateOrganization(ctx, payload, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "organization.created", organization.ID)
+	c.JSON(http.StatusCreated, dto.JsonResponse{Message: msg, Data: organization})
+}
+
+func (h *Handler) GetOrganizations(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	organizations, err := h.OrganizationUsecase.GetOrganizations(ctx, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: organizations})
+}
+
+func (h *Handler) GetOrganization(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	organization, err := h.OrganizationUsecase.GetOrganization(ctx, id, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: organization})
+}
+
+func (h *Handler) AddOrganizationMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	payload := dto.OrganizationMemberPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	member, err := h.OrganizationUsecase.AddMember(ctx, id, payload, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "organization.member_added", member.UserId)
+	c.JSON(http.StatusCreated, dto.JsonResponse{Message: msg, Data: member})
+}
+
+func (h *Handler) ChangeOrganizationMemberRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	payload := dto.OrganizationRolePayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	member, err := h.OrganizationUsecase.ChangeMemberRole(ctx, id, userId, payload, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "organization.member_changed", member.UserId, member.Role)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: member})
+}
+
+func (h *Handler) RemoveOrganizationMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	userId, err := strconv.Atoi(c.Param("userId"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	if err := h.OrganizationUsecase.RemoveMember(ctx, id, userId, principal); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "organization.member_removed", userId)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg})
+}
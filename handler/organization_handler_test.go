@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/handler"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/router"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganizationHandler_CreateOrganization(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should return status code 201 when the organization is created", func(t *testing.T) {
+		// 1. setup router
+		mockOrganizationUsecase := new(mocks.OrganizationUsecase)
+		h := handler.NewHandler(new(mocks.JobUsecase), new(mocks.UserUsecase), new(mocks.UserJobUsecase), mockOrganizationUsecase)
+		router := router.NewRouter(h, config.Default().Server, nil)
+		organization := dto.OrganizationResponse{ID: 5, Name: "acme", Role: "owner", CreatedAt: "2026-01-01 00:00:00"}
+		mockOrganizationUsecase.On("CreateOrganization", mock.Anything, dto.OrganizationPayload{Name: "acme"}, auth.Principal{}).Return(organization, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "successfully created organization with id 5", Data: organization})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/organizations", strings.NewReader(`{"name": " acme "}`))
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+}
+
+func TestOrganizationHandler_AddOrganizationMember(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should return status code 400 for an unknown role", func(t *testing.T) {
+		// 1. setup router
+		mockOrganizationUsecase := new(mocks.OrganizationUsecase)
+		h := handler.NewHandler(new(mocks.JobUsecase), new(mocks.UserUsecase), new(mocks.UserJobUsecase), mockOrganizationUsecase)
+		router := router.NewRouter(h, config.Default().Server, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/organizations/5/members", strings.NewReader(`{"user_id": 3, "role": "manager"}`))
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockOrganizationUsecase.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationHandler_RemoveOrganizationMember(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+
+	t.Run("should return status code 409 when removing the last owner", func(t *testing.T) {
+		// 1. setup router
+		mockOrganizationUsecase := new(mocks.OrganizationUsecase)
+		h := handler.NewHandler(new(mocks.JobUsecase), new(mocks.UserUsecase), new(mocks.UserJobUsecase), mockOrganizationUsecase)
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockOrganizationUsecase.On("RemoveMember", mock.Anything, 5, 2, auth.Principal{}).Return(shared.ErrLastOwner)
+		expectedResp, _ := json.Marshal(shared.ErrLastOwner.ToProblem("request-1"))
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/organizations/5/members/2", nil)
+		req.Header.Set("X-Request-ID", "request-1")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+}
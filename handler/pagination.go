@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/gin-gonic/gin"
+)
+
+func setPaginationLinks(c *gin.Context, pagination *dto.PaginationResponse) {
+	if pagination.Page < pagination.TotalPages {
+		pagination.Next = pageURL(c, pagination.Page+1)
+	}
+	if pagination.Page > 1 && pagination.TotalPages > 0 {
+		prev := pagination.Page - 1
+		if prev > pagination.TotalPages {
+			prev = pagination.TotalPages
+		}
+		pagination.Prev = pageURL(c, prev)
+	}
+}
+
+func pageURL(c *gin.Context, page int) string {
+	u := *c.Request.URL
+	q := u.Query()
+	q.Set("page", strconv.Itoa(page))
+	u.RawQuery = q.Encode()
+	return u.RequestURI()
+}
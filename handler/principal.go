@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/gin-gonic/gin"
+)
+
+// currentPrincipal returns the caller resolved by middleware.Auth, or the
+// zero Principal, which owns nothing, when the route is not authenticated.
+func currentPrincipal(c *gin.Context) auth.Principal {
+	principal, ok := c.Get(auth.PrincipalKey)
+	if !ok {
+		return auth.Principal{}
+	}
+	return principal.(auth.Principal)
+}
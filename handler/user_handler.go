@@ -3,9 +3,11 @@ package handler
 import (
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
 	"github.com/gin-gonic/gin"
 )
 
@@ -14,7 +16,7 @@ func (h *Handler) CreateUser(c *gin.Context) {
 	newUser := dto.UserPayload{}
 	if err := c.ShouldBindJSON(&newUser); err != nil {
 		log.Println(err)
-		c.Error(shared.ErrInvalidRequestBody)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
 		return
 	}
 
@@ -33,7 +35,7 @@ func (h *Handler) LoginUser(c *gin.Context) {
 	req := dto.LoginRequest{}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		log.Println(err)
-		c.Error(shared.ErrInvalidRequestBody)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
 		return
 	}
 
@@ -46,3 +48,201 @@ func (h *Handler) LoginUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+func (h *Handler) RefreshToken(c *gin.Context) {
+	ctx := c.Request.Context()
+	req := dto.RefreshRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	response, err := h.UserUsecase.RefreshToken(ctx, req)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *Handler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+	req := dto.LogoutRequest{}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			log.Println(err)
+			c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+			return
+		}
+	}
+
+	if err := h.UserUsecase.Logout(ctx, req, principal); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: message(c, "user.logged_out")})
+}
+
+func (h *Handler) GetProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	user, err := h.UserUsecase.GetProfile(ctx, principal.UserId)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: user})
+}
+
+func (h *Handler) UpdateProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+	req := dto.UpdateProfileRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	user, err := h.UserUsecase.UpdateProfile(ctx, principal.UserId, req)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	msg := message(c, "profile.updated")
+	if user.PendingEmail != "" {
+		msg = message(c, "profile.email_pending", user.PendingEmail)
+	}
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: user, Message: msg})
+}
+
+func (h *Handler) ConfirmEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+	req := dto.ConfirmEmailRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	user, err := h.UserUsecase.ConfirmEmail(ctx, req)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: user, Message: message(c, "email.changed")})
+}
+
+func (h *Handler) GetPublicProfile(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 0)
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	profile, err := h.UserUsecase.GetPublicProfile(ctx, uint(id))
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: profile})
+}
+
+func (h *Handler) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+	req := dto.ChangePasswordRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	if err := h.UserUsecase.ChangePassword(ctx, req, principal); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: message(c, "password.changed")})
+}
+
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	req := dto.ForgotPasswordRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	if err := h.UserUsecase.ForgotPassword(ctx, req); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.JsonResponse{Message: message(c, "password.reset_requested")})
+}
+
+func (h *Handler) ResetPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	req := dto.ResetPasswordRequest{}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	if err := h.UserUsecase.ResetPassword(ctx, req); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: message(c, "password.reset")})
+}
+
+func (h *Handler) VerifyEmail(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	user, err := h.UserUsecase.VerifyEmail(ctx, c.Request.URL.Query())
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: user, Message: message(c, "email.verified")})
+}
+
+func (h *Handler) ResendVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	if err := h.UserUsecase.ResendVerification(ctx, principal.UserId); err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, dto.JsonResponse{Message: message(c, "email.verification_sent")})
+}
@@ -3,29 +3,186 @@ package handler
 import (
 	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
 	"github.com/gin-gonic/gin"
 )
 
 func (h *Handler) ApplyJob(c *gin.Context) {
 	ctx := c.Request.Context()
-	userId := c.GetUint("id")
+	principal := currentPrincipal(c)
 	newUserJob := dto.UserJobsPayload{}
 
 	if err := c.ShouldBindJSON(&newUserJob); err != nil {
 		log.Println(err)
-		c.Error(shared.ErrInvalidRequestBody)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
 		return
 	}
 
-	users, err := h.UserJobUsecase.ApplyJob(ctx, newUserJob, int(userId))
+	application, err := h.UserJobUsecase.ApplyJob(ctx, newUserJob, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "application.applied", application.JobId)
+	c.JSON(http.StatusCreated, dto.JsonResponse{Message: msg, Data: application})
+}
+
+func (h *Handler) GetApplications(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+	query := dto.ApplicationsQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	applications, pagination, err := h.UserJobUsecase.GetApplications(ctx, principal, query)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: applications, Pagination: &pagination})
+}
+
+func (h *Handler) GetApplication(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	application, err := h.UserJobUsecase.GetApplication(ctx, id, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: application})
+}
+
+func (h *Handler) WithdrawApplication(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	application, err := h.UserJobUsecase.WithdrawApplication(ctx, id, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "application.withdrawn", application.ID)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: application})
+}
+
+func (h *Handler) ChangeApplicationStatus(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("applicationId"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	payload := dto.ApplicationStatusPayload{}
+	if err := c.ShouldBindJSON(&payload); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidRequestBody))
+		return
+	}
+
+	application, err := h.UserJobUsecase.ChangeApplicationStatus(ctx, jobId, id, payload, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+	msg := message(c, "application.moved", application.ID, application.Status)
+	c.JSON(http.StatusOK, dto.JsonResponse{Message: msg, Data: application})
+}
+
+func (h *Handler) GetApplicationHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("applicationId"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	histories, err := h.UserJobUsecase.GetApplicationHistory(ctx, jobId, id, principal)
+	if err != nil {
+		log.Println(err)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: histories})
+}
+
+func (h *Handler) GetJobApplicants(c *gin.Context) {
+	ctx := c.Request.Context()
+	principal := currentPrincipal(c)
+
+	jobId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		log.Println(err)
+		c.Error(shared.ErrIdNotFound)
+		return
+	}
+
+	query := dto.ApplicantsQuery{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		log.Println(err)
+		c.Error(validation.Translate(err, shared.ErrInvalidQueryParam))
+		return
+	}
+
+	applicants, pagination, err := h.UserJobUsecase.GetJobApplicants(ctx, jobId, query, principal)
 	if err != nil {
 		log.Println(err)
 		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusCreated, dto.JsonResponse{Data: users})
+	setPaginationLinks(c, &pagination)
+	c.JSON(http.StatusOK, dto.JsonResponse{Data: applicants, Pagination: &pagination})
 }
@@ -0,0 +1,208 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/handler"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/router"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func createApplicationResponse() dto.ApplicationResponse {
+	return dto.ApplicationResponse{
+		ID:        1,
+		Status:    "applied",
+		AppliedAt: "2023-06-10 20:00:00",
+		UpdatedAt: "2023-06-10 20:00:00",
+		Job: dto.ApplicationJobDTO{
+			ID:          1,
+			JobPosterId: 2,
+			JobName:     "test",
+			JobDesc:     "test",
+			Status:      "published",
+			ExpiryDate:  "2023-06-14 20:00:00",
+		},
+	}
+}
+
+func TestUserJobHandler_ApplyJob(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 201 with a translated message", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		application := dto.UserJobsDTO{JobId: 1, Status: "applied", AppliedAt: "2023-06-10 20:00:00"}
+		mockUserJobUsecase.On("ApplyJob", mock.Anything, dto.UserJobsPayload{UserId: 3, JobId: 1}, auth.Principal{}).Return(application, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Message: "berhasil melamar lowongan dengan id 1", Data: application})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/users/apply", strings.NewReader(`{"user_id":3,"job_id":1}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept-Language", "id")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+}
+
+func TestUserJobHandler_GetApplications(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 200 with the applicant's applications", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		applications := []dto.ApplicationResponse{createApplicationResponse()}
+		pagination := dto.PaginationResponse{Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1}
+		query := dto.ApplicationsQuery{Status: "applied"}
+		mockUserJobUsecase.On("GetApplications", mock.Anything, auth.Principal{}, query).Return(applications, pagination, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: applications, Pagination: &pagination})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/applications?status=applied", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 400 when status filter is unknown", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/applications?status=unknown", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestUserJobHandler_GetApplication(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 200 with the application", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		application := createApplicationResponse()
+		mockUserJobUsecase.On("GetApplication", mock.Anything, 1, auth.Principal{}).Return(application, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: application})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/applications/1", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return status code 404 when application belongs to someone else", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockUserJobUsecase.On("GetApplication", mock.Anything, 1, auth.Principal{}).Return(dto.ApplicationResponse{}, shared.ErrApplicationNotFound)
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/users/applications/1", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestUserJobHandler_GetJobApplicants(t *testing.T) {
+	t.Setenv("ENV_MODE", "testing")
+	t.Run("should return status code 200 with applicant profiles", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		applicants := []dto.ApplicantResponse{
+			{
+				ApplicationId: 1,
+				Status:        "interview",
+				AppliedAt:     "2023-06-10 20:00:00",
+				UpdatedAt:     "2023-06-11 20:00:00",
+				Applicant:     dto.ApplicantProfileDTO{ID: 3, Name: "applicant", Email: "applicant@test.local", Phone: "0812"},
+			},
+		}
+		pagination := dto.PaginationResponse{Page: 1, Limit: 10, TotalItems: 1, TotalPages: 1}
+		query := dto.ApplicantsQuery{Status: "interview", Sort: dto.SortApplicantsOldest}
+		mockUserJobUsecase.On("GetJobApplicants", mock.Anything, 1, query, auth.Principal{}).Return(applicants, pagination, nil)
+		expectedResp, _ := json.Marshal(dto.JsonResponse{Data: applicants, Pagination: &pagination})
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1/applicants?status=interview&sort=oldest", nil)
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, http.StatusOK, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+
+	t.Run("should return error when caller does not own the job", func(t *testing.T) {
+		// 1. setup router
+		mockJobUsecase := new(mocks.JobUsecase)
+		mockUserUsecase := new(mocks.UserUsecase)
+		mockUserJobUsecase := new(mocks.UserJobUsecase)
+		h := handler.NewHandler(mockJobUsecase, mockUserUsecase, mockUserJobUsecase, new(mocks.OrganizationUsecase))
+		router := router.NewRouter(h, config.Default().Server, nil)
+		mockUserJobUsecase.On("GetJobApplicants", mock.Anything, 1, dto.ApplicantsQuery{}, auth.Principal{}).Return(nil, dto.PaginationResponse{}, shared.ErrForbidden)
+		expectedResp, _ := json.Marshal(shared.ErrForbidden.ToProblem("request-1"))
+
+		// 2. make request
+		rec := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/jobs/1/applicants", nil)
+		req.Header.Set("X-Request-ID", "request-1")
+		router.ServeHTTP(rec, req)
+
+		// 3. assert
+		assert.Equal(t, shared.ProblemContentType, rec.Header().Get("Content-Type"))
+		assert.Equal(t, shared.ErrForbidden.StatusCode, rec.Code)
+		str := strings.Trim(rec.Body.String(), "\n")
+		assert.Equal(t, string(expectedResp), str)
+	})
+}
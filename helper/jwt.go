@@ -1,31 +1,78 @@
 package helper
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"time"
 
+	"github.com/adityatresnobudi/job-portal/config"
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/shared"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var APPLICATION_NAME = "Library"
-var JWT_SIGNING_METHOD = jwt.SigningMethodHS256
-var JWT_SIGNATURE_KEY = []byte("3 permen milkita sama dengan 1 gelas susu")
+// JWT signs access tokens with the active key of its key set and validates
+// them against any key that is still trusted, matched by the kid header.
+type JWT struct {
+	issuer          string
+	keys            *keySet
+	rotationPeriod  time.Duration
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
+}
 
 type JWTClaims struct {
 	jwt.RegisteredClaims
-	UserId uint `json:"id"`
+	UserId      uint     `json:"id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	// EmailVerified is fixed when the token is issued; a user who verifies
+	// their email gets the new value on the next refresh.
+	EmailVerified bool `json:"email_verified"`
+}
+
+func NewJWT(cfg config.JWT) (*JWT, error) {
+	keys, err := newKeySet(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWT{
+		issuer:          cfg.Issuer,
+		keys:            keys,
+		rotationPeriod:  cfg.RotationPeriod,
+		accessTokenTTL:  cfg.AccessTokenTTL,
+		refreshTokenTTL: cfg.RefreshTokenTTL,
+	}, nil
+}
+
+func (j *JWT) AccessTokenTTL() time.Duration {
+	return j.accessTokenTTL
+}
+
+func (j *JWT) RefreshTokenTTL() time.Duration {
+	return j.refreshTokenTTL
 }
 
-func AuthorizedJWT(claims JWTClaims, user dto.UserPayload) (string, error) {
-	claims.Issuer = APPLICATION_NAME
+func (j *JWT) AuthorizedJWT(claims JWTClaims, user dto.UserPayload) (string, error) {
+	claims.Issuer = j.issuer
 	claims.IssuedAt = jwt.NewNumericDate(time.Now())
-	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(1 * time.Hour))
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(j.accessTokenTTL))
 	claims.UserId = user.ID
 
-	token := jwt.NewWithClaims(JWT_SIGNING_METHOD, claims)
+	jti, err := RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+	claims.ID = jti
+
+	key := j.keys.active()
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
 
-	generateToken, err := token.SignedString([]byte(JWT_SIGNATURE_KEY))
+	generateToken, err := token.SignedString(key.sign)
 	if err != nil {
 		return "", err
 	}
@@ -33,13 +80,51 @@ func AuthorizedJWT(claims JWTClaims, user dto.UserPayload) (string, error) {
 	return generateToken, nil
 }
 
-func ValidateJWT(generateToken string) (*jwt.Token, error) {
+func (j *JWT) ValidateJWT(generateToken string) (*jwt.Token, error) {
 	return jwt.ParseWithClaims(generateToken, &JWTClaims{}, func(t *jwt.Token) (interface{}, error) {
-		_, ok := t.Method.(*jwt.SigningMethodHMAC)
-		if !ok {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := j.keys.find(kid)
+		if !ok || t.Method.Alg() != key.method.Alg() {
 			return nil, shared.ErrInvalidToken
 		}
 
-		return JWT_SIGNATURE_KEY, nil
-	})
+		return key.verify, nil
+	}, jwt.WithIssuer(j.issuer))
+}
+
+// RotateIfDue replaces the signing key once it is older than the configured
+// rotation period and forgets retired keys whose tokens have all expired. It
+// reports the new kid, or "" when nothing was rotated.
+func (j *JWT) RotateIfDue(now time.Time) (string, error) {
+	if j.rotationPeriod <= 0 {
+		return "", nil
+	}
+
+	if now.Before(j.keys.active().createdAt.Add(j.rotationPeriod)) {
+		j.keys.forget(now)
+		return "", nil
+	}
+
+	return j.keys.rotate(now)
+}
+
+// JWKS returns the public keys tokens may currently be verified with.
+func (j *JWT) JWKS() JSONWebKeySet {
+	return j.keys.publicKeys()
+}
+
+// RandomToken returns n random bytes encoded as unpadded base64url.
+func RandomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the hex SHA-256 of an opaque token. Only the hash of a
+// refresh token is stored so a database leak does not hand out sessions.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,301 @@
+package helper
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const rsaKeyBits = 2048
+
+type signingKey struct {
+	kid       string
+	method    jwt.SigningMethod
+	sign      interface{}
+	verify    interface{}
+	public    crypto.PublicKey
+	createdAt time.Time
+	retiredAt time.Time
+}
+
+// keySet holds the active signing key and the retired keys that still verify
+// tokens they signed. A retired key is dropped once every token it could have
+// signed has expired.
+type keySet struct {
+	mu        sync.RWMutex
+	algorithm string
+	dir       string
+	grace     time.Duration
+	keys      []*signingKey
+}
+
+func newKeySet(cfg config.JWT) (*keySet, error) {
+	ks := &keySet{
+		algorithm: cfg.Algorithm,
+		dir:       cfg.KeyDir,
+		grace:     cfg.AccessTokenTTL,
+	}
+
+	if cfg.Algorithm == config.JWTAlgorithmHS256 {
+		key := []byte(cfg.Secret.Value())
+		ks.keys = []*signingKey{{
+			kid:       "hs256",
+			method:    jwt.SigningMethodHS256,
+			sign:      key,
+			verify:    key,
+			createdAt: time.Now(),
+		}}
+		return ks, nil
+	}
+
+	if ks.dir != "" {
+		if err := ks.load(); err != nil {
+			return nil, err
+		}
+	}
+	if len(ks.keys) == 0 {
+		if _, err := ks.rotate(time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	return ks, nil
+}
+
+// load reads every <kid>.pem in dir. The most recently written key signs and
+// each older key counts as retired when its successor was written.
+func (ks *keySet) load() error {
+	entries, err := os.ReadDir(ks.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".pem" {
+			continue
+		}
+
+		path := filepath.Join(ks.dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		key, err := ks.parse(strings.TrimSuffix(entry.Name(), ".pem"), data)
+		if err != nil {
+			return fmt.Errorf("loading jwt key %s: %w", path, err)
+		}
+		key.createdAt = info.ModTime()
+		ks.keys = append(ks.keys, key)
+	}
+
+	sort.Slice(ks.keys, func(i, j int) bool {
+		return ks.keys[i].createdAt.Before(ks.keys[j].createdAt)
+	})
+	for i := 0; i < len(ks.keys)-1; i++ {
+		ks.keys[i].retiredAt = ks.keys[i+1].createdAt
+	}
+
+	return nil
+}
+
+func (ks *keySet) parse(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if ks.algorithm != config.JWTAlgorithmRS256 {
+			return nil, fmt.Errorf("RSA key does not match algorithm %s", ks.algorithm)
+		}
+		return &signingKey{kid: kid, method: jwt.SigningMethodRS256, sign: private, verify: &private.PublicKey, public: &private.PublicKey}, nil
+	case ed25519.PrivateKey:
+		if ks.algorithm != config.JWTAlgorithmEdDSA {
+			return nil, fmt.Errorf("Ed25519 key does not match algorithm %s", ks.algorithm)
+		}
+		public := private.Public().(ed25519.PublicKey)
+		return &signingKey{kid: kid, method: jwt.SigningMethodEdDSA, sign: private, verify: public, public: public}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", private)
+	}
+}
+
+func (ks *keySet) generate(now time.Time) (*signingKey, crypto.Signer, error) {
+	suffix, err := RandomToken(4)
+	if err != nil {
+		return nil, nil, err
+	}
+	kid := now.UTC().Format("20060102T150405Z") + "-" + suffix
+
+	switch ks.algorithm {
+	case config.JWTAlgorithmRS256:
+		private, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &signingKey{kid: kid, method: jwt.SigningMethodRS256, sign: private, verify: &private.PublicKey, public: &private.PublicKey, createdAt: now}, private, nil
+	case config.JWTAlgorithmEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &signingKey{kid: kid, method: jwt.SigningMethodEdDSA, sign: private, verify: public, public: public, createdAt: now}, private, nil
+	default:
+		return nil, nil, fmt.Errorf("cannot rotate %s keys", ks.algorithm)
+	}
+}
+
+// rotate makes a freshly generated key the signing key, retires the previous
+// one and drops keys whose grace period is over. With a key dir the new key is
+// written before it is used so a restart keeps verifying its tokens.
+func (ks *keySet) rotate(now time.Time) (string, error) {
+	key, private, err := ks.generate(now)
+	if err != nil {
+		return "", err
+	}
+
+	if ks.dir != "" {
+		der, err := x509.MarshalPKCS8PrivateKey(private)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(ks.dir, 0o700); err != nil {
+			return "", err
+		}
+		data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+		if err := os.WriteFile(filepath.Join(ks.dir, key.kid+".pem"), data, 0o600); err != nil {
+			return "", err
+		}
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if n := len(ks.keys); n > 0 {
+		ks.keys[n-1].retiredAt = now
+	}
+	ks.keys = append(ks.keys, key)
+	ks.prune(now)
+
+	return key.kid, nil
+}
+
+func (ks *keySet) forget(now time.Time) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.prune(now)
+}
+
+// prune must be called with mu held.
+func (ks *keySet) prune(now time.Time) {
+	kept := ks.keys[:0]
+	for _, key := range ks.keys {
+		if !key.retiredAt.IsZero() && now.After(key.retiredAt.Add(ks.grace)) {
+			if ks.dir != "" {
+				os.Remove(filepath.Join(ks.dir, key.kid+".pem"))
+			}
+			continue
+		}
+		kept = append(kept, key)
+	}
+	ks.keys = kept
+}
+
+func (ks *keySet) active() *signingKey {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	return ks.keys[len(ks.keys)-1]
+}
+
+func (ks *keySet) find(kid string) (*signingKey, bool) {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	for _, key := range ks.keys {
+		if key.kid == kid {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// JSONWebKey is the public half of a signing key as published in a JWKS.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// publicKeys lists the keys other services may verify with. HMAC keys are
+// secret and never listed.
+func (ks *keySet) publicKeys() JSONWebKeySet {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	set := JSONWebKeySet{Keys: []JSONWebKey{}}
+	for _, key := range ks.keys {
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "RSA",
+				Kid: key.kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			set.Keys = append(set.Keys, JSONWebKey{
+				Kty: "OKP",
+				Kid: key.kid,
+				Use: "sig",
+				Alg: key.method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			})
+		}
+	}
+
+	return set
+}
@@ -0,0 +1,131 @@
+package helper_test
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/helper"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func jwtConfig(algorithm string) config.JWT {
+	cfg := config.Default().JWT
+	cfg.Algorithm = algorithm
+	cfg.Secret = "0123456789abcdef0123456789abcdef"
+	cfg.RotationPeriod = 24 * time.Hour
+	return cfg
+}
+
+func TestJWT_SignAndValidate(t *testing.T) {
+	for _, algorithm := range []string{config.JWTAlgorithmHS256, config.JWTAlgorithmRS256, config.JWTAlgorithmEdDSA} {
+		t.Run("should validate its own "+algorithm+" token", func(t *testing.T) {
+			cfg := jwtConfig(algorithm)
+			if algorithm == config.JWTAlgorithmHS256 {
+				cfg.RotationPeriod = 0
+			}
+			tokens, err := helper.NewJWT(cfg)
+			require.NoError(t, err)
+
+			signed, err := tokens.AuthorizedJWT(helper.JWTClaims{Role: "seeker"}, dto.UserPayload{ID: 7})
+			require.NoError(t, err)
+			token, err := tokens.ValidateJWT(signed)
+
+			require.NoError(t, err)
+			assert.Equal(t, algorithm, token.Method.Alg())
+			assert.Equal(t, uint(7), token.Claims.(*helper.JWTClaims).UserId)
+		})
+	}
+
+	t.Run("should reject a token signed with an unknown key", func(t *testing.T) {
+		tokens, err := helper.NewJWT(jwtConfig(config.JWTAlgorithmEdDSA))
+		require.NoError(t, err)
+		other, err := helper.NewJWT(jwtConfig(config.JWTAlgorithmEdDSA))
+		require.NoError(t, err)
+
+		signed, err := other.AuthorizedJWT(helper.JWTClaims{}, dto.UserPayload{ID: 7})
+		require.NoError(t, err)
+		_, err = tokens.ValidateJWT(signed)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestJWT_RotateIfDue(t *testing.T) {
+	t.Run("should keep verifying tokens of a retired key until they expire", func(t *testing.T) {
+		cfg := jwtConfig(config.JWTAlgorithmEdDSA)
+		tokens, err := helper.NewJWT(cfg)
+		require.NoError(t, err)
+		signed, err := tokens.AuthorizedJWT(helper.JWTClaims{}, dto.UserPayload{ID: 7})
+		require.NoError(t, err)
+
+		kid, err := tokens.RotateIfDue(time.Now())
+		require.NoError(t, err)
+		assert.Empty(t, kid)
+
+		kid, err = tokens.RotateIfDue(time.Now().Add(cfg.RotationPeriod))
+		require.NoError(t, err)
+		assert.NotEmpty(t, kid)
+		_, err = tokens.ValidateJWT(signed)
+		assert.NoError(t, err)
+		assert.Len(t, tokens.JWKS().Keys, 2)
+
+		_, err = tokens.RotateIfDue(time.Now().Add(cfg.RotationPeriod + cfg.AccessTokenTTL + time.Minute))
+		require.NoError(t, err)
+		assert.Len(t, tokens.JWKS().Keys, 1)
+		assert.Equal(t, kid, tokens.JWKS().Keys[0].Kid)
+	})
+
+	t.Run("should persist keys and load them again", func(t *testing.T) {
+		cfg := jwtConfig(config.JWTAlgorithmRS256)
+		cfg.KeyDir = filepath.Join(t.TempDir(), "keys")
+		tokens, err := helper.NewJWT(cfg)
+		require.NoError(t, err)
+		signed, err := tokens.AuthorizedJWT(helper.JWTClaims{}, dto.UserPayload{ID: 7})
+		require.NoError(t, err)
+
+		files, err := os.ReadDir(cfg.KeyDir)
+		require.NoError(t, err)
+		assert.Len(t, files, 1)
+
+		restarted, err := helper.NewJWT(cfg)
+		require.NoError(t, err)
+		_, err = restarted.ValidateJWT(signed)
+		assert.NoError(t, err)
+	})
+}
+
+func TestJWT_JWKS(t *testing.T) {
+	t.Run("should publish Ed25519 public keys", func(t *testing.T) {
+		tokens, err := helper.NewJWT(jwtConfig(config.JWTAlgorithmEdDSA))
+		require.NoError(t, err)
+		signed, err := tokens.AuthorizedJWT(helper.JWTClaims{}, dto.UserPayload{ID: 7})
+		require.NoError(t, err)
+
+		keys := tokens.JWKS().Keys
+		require.Len(t, keys, 1)
+		assert.Equal(t, "OKP", keys[0].Kty)
+		public, err := base64.RawURLEncoding.DecodeString(keys[0].X)
+		require.NoError(t, err)
+		_, err = jwt.Parse(signed, func(*jwt.Token) (interface{}, error) {
+			return ed25519.PublicKey(public), nil
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("should never publish HMAC keys", func(t *testing.T) {
+		cfg := jwtConfig(config.JWTAlgorithmHS256)
+		cfg.RotationPeriod = 0
+		tokens, err := helper.NewJWT(cfg)
+		require.NoError(t, err)
+
+		assert.Empty(t, tokens.JWKS().Keys)
+	})
+}
@@ -0,0 +1,76 @@
+package helper
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrInvalidSignature = errors.New("link signature is invalid")
+	ErrLinkExpired      = errors.New("link has expired")
+)
+
+// LinkSigner signs the query of a link with HMAC-SHA256 so the link can be
+// checked later without storing anything.
+type LinkSigner struct {
+	key []byte
+}
+
+func NewLinkSigner(secret string) *LinkSigner {
+	return &LinkSigner{
+		key: []byte(secret),
+	}
+}
+
+// Sign returns a copy of values with an expires and a signature parameter
+// added. Every other parameter is covered by the signature.
+func (s *LinkSigner) Sign(values url.Values, expiresAt time.Time) url.Values {
+	signed := url.Values{}
+	for k, v := range values {
+		signed[k] = append([]string(nil), v...)
+	}
+	signed.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	signed.Set("signature", s.signature(signed))
+	return signed
+}
+
+// Verify checks the signature of values made by Sign and that the link has
+// not expired at now.
+func (s *LinkSigner) Verify(values url.Values, now time.Time) error {
+	got, err := base64.RawURLEncoding.DecodeString(values.Get("signature"))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	want, _ := base64.RawURLEncoding.DecodeString(s.signature(values))
+	if !hmac.Equal(got, want) {
+		return ErrInvalidSignature
+	}
+
+	expires, err := strconv.ParseInt(values.Get("expires"), 10, 64)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if !now.Before(time.Unix(expires, 0)) {
+		return ErrLinkExpired
+	}
+
+	return nil
+}
+
+func (s *LinkSigner) signature(values url.Values) string {
+	unsigned := url.Values{}
+	for k, v := range values {
+		if k != "signature" {
+			unsigned[k] = v
+		}
+	}
+
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(unsigned.Encode()))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
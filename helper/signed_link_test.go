@@ -0,0 +1,40 @@
+package helper_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/helper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkSigner(t *testing.T) {
+	signer := helper.NewLinkSigner("0123456789abcdef0123456789abcdef")
+	now := time.Now()
+	signed := signer.Sign(url.Values{"user": {"3"}, "email": {"seeker@mail.com"}}, now.Add(time.Hour))
+
+	t.Run("should accept an untouched link before it expires", func(t *testing.T) {
+		assert.NoError(t, signer.Verify(signed, now))
+	})
+
+	t.Run("should reject a link with a changed parameter", func(t *testing.T) {
+		tampered := url.Values{}
+		for k, v := range signed {
+			tampered[k] = v
+		}
+		tampered.Set("email", "attacker@mail.com")
+
+		assert.ErrorIs(t, signer.Verify(tampered, now), helper.ErrInvalidSignature)
+	})
+
+	t.Run("should reject a link signed with another key", func(t *testing.T) {
+		other := helper.NewLinkSigner("fedcba9876543210fedcba9876543210")
+
+		assert.ErrorIs(t, other.Verify(signed, now), helper.ErrInvalidSignature)
+	})
+
+	t.Run("should reject an expired link", func(t *testing.T) {
+		assert.ErrorIs(t, signer.Verify(signed, now.Add(2*time.Hour)), helper.ErrLinkExpired)
+	})
+}
@@ -0,0 +1,58 @@
+package i18n
+
+// english holds the success and field error messages and the problem titles,
+// keyed by status code. Error details need no entry here:
+// shared.CustomError carries its English message itself.
+var english = map[string]string{
+	"job.created":                 "successfully add post job with id %d",
+	"job.status.published":        "successfully published job with id %d",
+	"job.status.paused":           "successfully paused job with id %d",
+	"job.status.closed":           "successfully closed job with id %d",
+	"job.status.archived":         "successfully archived job with id %d",
+	"job.changed":                 "successfully change job with id %d",
+	"job.deleted":                 "successfully deleted job with id %d",
+	"job.restored":                "successfully restored job with id %d",
+	"user.deleted":                "successfully deleted user with id %d",
+	"user.restored":               "successfully restored user with id %d",
+	"user.logged_out":             "successfully logged out",
+	"profile.updated":             "successfully updated profile",
+	"profile.email_pending":       "successfully updated profile, confirm %s to change your email",
+	"email.changed":               "successfully changed email",
+	"email.verified":              "successfully verified email, refresh your access token to use it",
+	"email.verification_sent":     "verification email sent",
+	"password.changed":            "successfully changed password, sign in again with the new password",
+	"password.reset_requested":    "if the email belongs to an account, a password reset token has been sent to it",
+	"password.reset":              "successfully reset password, sign in with the new password",
+	"application.applied":         "successfully applied to job with id %d",
+	"application.withdrawn":       "successfully withdrew application with id %d",
+	"application.moved":           "successfully moved application with id %d to %s",
+	"application.restored":        "successfully restored application with id %d",
+	"organization.created":        "successfully created organization with id %d",
+	"organization.member_added":   "successfully added user with id %d to the organization",
+	"organization.member_changed": "successfully changed the role of user with id %d to %s",
+	"organization.member_removed": "successfully removed user with id %d from the organization",
+
+	"field.required":         "%s is required",
+	"field.invalid_email":    "%s must be a valid email address",
+	"field.invalid_phone":    "%s must be a phone number of 8 to 15 digits",
+	"field.invalid_datetime": "%s must be a date time like %s",
+	"field.not_in_future":    "%s must be in the future",
+	"field.too_short":        "%s must be at least %s characters",
+	"field.too_long":         "%s must be at most %s characters",
+	"field.too_small":        "%s must be at least %s",
+	"field.too_large":        "%s must be at most %s",
+	"field.invalid_choice":   "%s must be one of %s",
+	"field.invalid_type":     "%s must be a %s",
+	"field.invalid":          "%s is invalid",
+	"field.unknown_field":    "%s is not a field that can be changed",
+
+	"title.400": "Bad Request",
+	"title.401": "Unauthorized",
+	"title.403": "Forbidden",
+	"title.404": "Not Found",
+	"title.409": "Conflict",
+	"title.412": "Precondition Failed",
+	"title.415": "Unsupported Media Type",
+	"title.428": "Precondition Required",
+	"title.500": "Internal Server Error",
+}
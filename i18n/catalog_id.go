@@ -0,0 +1,140 @@
+package i18n
+
+// indonesian translates the success and field error messages and the problem
+// titles as well as the error details, which are keyed by their
+// shared.CustomError code.
+var indonesian = map[string]string{
+	"job.created":                 "berhasil menambahkan lowongan dengan id %d",
+	"job.status.published":        "berhasil menerbitkan lowongan dengan id %d",
+	"job.status.paused":           "berhasil menjeda lowongan dengan id %d",
+	"job.status.closed":           "berhasil menutup lowongan dengan id %d",
+	"job.status.archived":         "berhasil mengarsipkan lowongan dengan id %d",
+	"job.changed":                 "berhasil mengubah lowongan dengan id %d",
+	"job.deleted":                 "berhasil menghapus lowongan dengan id %d",
+	"job.restored":                "berhasil memulihkan lowongan dengan id %d",
+	"user.deleted":                "berhasil menghapus pengguna dengan id %d",
+	"user.restored":               "berhasil memulihkan pengguna dengan id %d",
+	"user.logged_out":             "berhasil keluar",
+	"profile.updated":             "berhasil memperbarui profil",
+	"profile.email_pending":       "berhasil memperbarui profil, konfirmasi %s untuk mengganti email Anda",
+	"email.changed":               "berhasil mengganti email",
+	"email.verified":              "berhasil memverifikasi email, perbarui token akses Anda untuk menggunakannya",
+	"email.verification_sent":     "email verifikasi telah dikirim",
+	"password.changed":            "berhasil mengganti kata sandi, masuk kembali dengan kata sandi baru",
+	"password.reset_requested":    "jika email terdaftar pada sebuah akun, token atur ulang kata sandi telah dikirim ke email tersebut",
+	"password.reset":              "berhasil mengatur ulang kata sandi, masuk dengan kata sandi baru",
+	"application.applied":         "berhasil melamar lowongan dengan id %d",
+	"application.withdrawn":       "berhasil menarik lamaran dengan id %d",
+	"application.moved":           "berhasil memindahkan lamaran dengan id %d ke tahap %s",
+	"application.restored":        "berhasil memulihkan lamaran dengan id %d",
+	"organization.created":        "berhasil membuat organisasi dengan id %d",
+	"organization.member_added":   "berhasil menambahkan pengguna dengan id %d ke organisasi",
+	"organization.member_changed": "berhasil mengubah peran pengguna dengan id %d menjadi %s",
+	"organization.member_removed": "berhasil mengeluarkan pengguna dengan id %d dari organisasi",
+
+	"field.required":         "%s wajib diisi",
+	"field.invalid_email":    "%s harus berupa alamat email yang valid",
+	"field.invalid_phone":    "%s harus berupa nomor telepon 8 sampai 15 digit",
+	"field.invalid_datetime": "%s harus berupa tanggal dan waktu seperti %s",
+	"field.not_in_future":    "%s harus berada di masa depan",
+	"field.too_short":        "%s minimal %s karakter",
+	"field.too_long":         "%s maksimal %s karakter",
+	"field.too_small":        "%s minimal %s",
+	"field.too_large":        "%s maksimal %s",
+	"field.invalid_choice":   "%s harus salah satu dari %s",
+	"field.invalid_type":     "%s harus bertipe %s",
+	"field.invalid":          "%s tidak valid",
+	"field.unknown_field":    "%s bukan kolom yang dapat diubah",
+
+	"title.400": "Permintaan Tidak Valid",
+	"title.401": "Tidak Terautentikasi",
+	"title.403": "Akses Ditolak",
+	"title.404": "Tidak Ditemukan",
+	"title.409": "Konflik",
+	"title.412": "Prasyarat Gagal",
+	"title.415": "Jenis Media Tidak Didukung",
+	"title.428": "Prasyarat Diperlukan",
+	"title.500": "Kesalahan Server Internal",
+
+	"getting_jobs":                   "gagal mengambil daftar lowongan",
+	"creating_jobs":                  "gagal membuat lowongan",
+	"invalid_request_body":           "isi permintaan tidak valid",
+	"invalid_query_param":            "parameter kueri tidak valid",
+	"finding_jobs":                   "gagal mencari lowongan",
+	"searching_jobs":                 "gagal melakukan pencarian lowongan",
+	"invalid_search_query":           "kueri pencarian tidak memiliki kata yang dapat dicari",
+	"id_not_found":                   "id tidak ditemukan",
+	"record_not_found":               "data tidak ditemukan",
+	"create_users":                   "gagal membuat pengguna",
+	"invalid_token":                  "token tidak valid",
+	"token_revoked":                  "token telah dicabut",
+	"verifying_token":                "gagal memverifikasi token",
+	"invalid_refresh_token":          "token penyegaran tidak valid",
+	"refresh_token_reused":           "token penyegaran sudah pernah dipakai, sesi dicabut",
+	"refreshing_token":               "gagal memperbarui token",
+	"failed_logout":                  "gagal keluar",
+	"invalid_auth_header":            "header otorisasi tidak valid",
+	"job_not_found":                  "lowongan tidak ditemukan",
+	"forbidden":                      "akses ditolak",
+	"unauthorized":                   "autentikasi diperlukan",
+	"unknown_job_action":             "aksi lowongan tidak dikenal",
+	"illegal_transition":             "status lowongan tidak mengizinkan aksi ini",
+	"job_status_conflict":            "status lowongan telah diubah oleh permintaan lain",
+	"updating_job_status":            "gagal mengubah status lowongan",
+	"minus_quota":                    "kuota kurang dari nol",
+	"job_transaction":                "gagal memproses transaksi lowongan",
+	"create_apply_job":               "gagal mengirim lamaran",
+	"getting_user_job":               "gagal mengambil lamaran",
+	"already_applied":                "sudah melamar lowongan ini",
+	"application_not_found":          "lamaran tidak ditemukan",
+	"getting_applications":           "gagal mengambil daftar lamaran",
+	"illegal_application_transition": "status lamaran tidak mengizinkan perubahan ini",
+	"updating_application_status":    "gagal mengubah status lamaran",
+	"cannot_withdraw":                "lamaran tidak dapat ditarik lagi",
+	"application_status_conflict":    "status lamaran telah diubah oleh permintaan lain",
+	"withdrawing_application":        "gagal menarik lamaran",
+	"job_full":                       "kuota lowongan sudah penuh",
+	"user_not_found":                 "pengguna tidak ditemukan",
+	"deleting_user":                  "gagal menghapus pengguna",
+	"restoring_user":                 "gagal memulihkan pengguna",
+	"email_taken":                    "email sudah digunakan oleh akun lain",
+	"updating_job":                   "gagal mengubah lowongan",
+	"job_version_mismatch":           "lowongan telah diubah oleh permintaan lain, ambil ulang lalu coba lagi",
+	"job_precondition_required":      "kirim ETag lowongan pada If-Match untuk mengubahnya",
+	"unsupported_media_type":         "tipe konten tidak didukung",
+	"job_revision_not_found":         "revisi lowongan tidak ditemukan",
+	"getting_job_revisions":          "gagal mengambil revisi lowongan",
+	"deleting_job":                   "gagal menghapus lowongan",
+	"restoring_job":                  "gagal memulihkan lowongan",
+	"job_organization_missing":       "organisasi pemilik lowongan sudah tidak ada",
+	"restoring_application":          "gagal memulihkan lamaran",
+	"getting_deleted_records":        "gagal mengambil data yang dihapus",
+	"getting_profile":                "gagal mengambil profil",
+	"updating_profile":               "gagal memperbarui profil",
+	"invalid_email_token":            "token konfirmasi email tidak valid atau sudah kedaluwarsa",
+	"changing_email":                 "gagal mengganti email",
+	"wrong_password":                 "kata sandi saat ini salah",
+	"changing_password":              "gagal mengganti kata sandi",
+	"requesting_password_reset":      "gagal meminta atur ulang kata sandi",
+	"invalid_password_reset_token":   "token atur ulang kata sandi tidak valid atau sudah kedaluwarsa",
+	"resetting_password":             "gagal mengatur ulang kata sandi",
+	"email_not_verified":             "verifikasi email Anda terlebih dahulu",
+	"email_already_verified":         "email sudah terverifikasi",
+	"invalid_verification_link":      "tautan verifikasi email tidak valid",
+	"verification_link_expired":      "tautan verifikasi email sudah kedaluwarsa, minta tautan baru",
+	"verifying_email":                "gagal memverifikasi email",
+	"sending_verification":           "gagal mengirim email verifikasi",
+	"organization_not_found":         "organisasi tidak ditemukan",
+	"creating_organization":          "gagal membuat organisasi",
+	"getting_organizations":          "gagal mengambil organisasi",
+	"member_not_found":               "anggota organisasi tidak ditemukan",
+	"already_member":                 "pengguna sudah menjadi anggota organisasi",
+	"last_owner":                     "organisasi harus memiliki setidaknya satu pemilik",
+	"poster_not_member":              "pemasang lowongan bukan anggota organisasi",
+	"updating_members":               "gagal mengubah anggota organisasi",
+	"checking_membership":            "gagal memeriksa keanggotaan organisasi",
+	"invalid_credentials":            "email atau kata sandi salah",
+	"failed_login":                   "gagal masuk",
+	"internal_error":                 "terjadi kesalahan yang tidak terduga",
+	"validation_failed":              "permintaan tidak lolos validasi",
+}
@@ -0,0 +1,78 @@
+// Package i18n holds the message catalogs of the API and picks the language
+// a request is answered in.
+package i18n
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	English    = "en"
+	Indonesian = "id"
+)
+
+// DefaultLanguage answers requests that accept none of the supported
+// languages. Its catalog is the fallback for keys another catalog lacks.
+const DefaultLanguage = English
+
+// LanguageKey is the gin context key the language middleware stores the
+// negotiated language under.
+const LanguageKey = "language"
+
+var catalogs = map[string]map[string]string{
+	English:    english,
+	Indonesian: indonesian,
+}
+
+// Negotiate picks the supported language the Accept-Language header prefers
+// most. Only the primary subtag is compared, so "id-ID" selects Indonesian.
+func Negotiate(acceptLanguage string) string {
+	best, bestWeight := DefaultLanguage, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, weight := parseLanguageRange(part)
+		lang := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := catalogs[lang]; ok && weight > bestWeight {
+			best, bestWeight = lang, weight
+		}
+	}
+	return best
+}
+
+func parseLanguageRange(part string) (string, float64) {
+	tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+	weight := 1.0
+	for _, param := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.TrimSpace(name) != "q" {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || q < 0 || q > 1 {
+			return tag, 0
+		}
+		weight = q
+	}
+	return strings.TrimSpace(tag), weight
+}
+
+// Lookup returns the template of key in lang, falling back to the default
+// language. ok is false when neither catalog has the key.
+func Lookup(lang, key string) (string, bool) {
+	if template, ok := catalogs[lang][key]; ok {
+		return template, true
+	}
+	template, ok := catalogs[DefaultLanguage][key]
+	return template, ok
+}
+
+// Translate formats the template of key in lang with args. An unknown key
+// is returned as is, so a missing entry shows up instead of an empty message.
+func Translate(lang, key string, args ...interface{}) string {
+	template, ok := Lookup(lang, key)
+	if !ok {
+		return key
+	}
+	return fmt.Sprintf(template, args...)
+}
@@ -0,0 +1,129 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{header: "", expected: English},
+		{header: "id", expected: Indonesian},
+		{header: "id-ID,id;q=0.9,en-US;q=0.8", expected: Indonesian},
+		{header: "en-US,en;q=0.9,id;q=0.8", expected: English},
+		{header: "fr-FR, id;q=0.5", expected: Indonesian},
+		{header: "en;q=0.4, ID;q=0.6", expected: Indonesian},
+		{header: "id;q=0, en;q=0.1", expected: English},
+		{header: "fr, de", expected: English},
+		{header: "*", expected: English},
+		{header: "id;q=abc", expected: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Negotiate(tt.header))
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	t.Run("should format the template of the language", func(t *testing.T) {
+		assert.Equal(t, "berhasil menghapus lowongan dengan id 7", Translate(Indonesian, "job.deleted", 7))
+		assert.Equal(t, "successfully deleted job with id 7", Translate(English, "job.deleted", 7))
+	})
+
+	t.Run("should fall back to the default language", func(t *testing.T) {
+		assert.Equal(t, "successfully deleted job with id 7", Translate("fr", "job.deleted", 7))
+		assert.Equal(t, "successfully deleted job with id 7", Translate("", "job.deleted", 7))
+	})
+
+	t.Run("should return an unknown key as is", func(t *testing.T) {
+		assert.Equal(t, "job.unknown", Translate(Indonesian, "job.unknown"))
+	})
+}
+
+var verb = regexp.MustCompile(`%[a-z]`)
+
+// TestCatalogs keeps the catalogs in step: every default language entry and
+// every error code is translated and takes the same arguments.
+func TestCatalogs(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key, template := range english {
+			translated, ok := catalog[key]
+			if !assert.True(t, ok, "%s has no %q", lang, key) {
+				continue
+			}
+			assert.Equal(t, verb.FindAllString(template, -1), verb.FindAllString(translated, -1), "%s %q", lang, key)
+		}
+		for _, code := range errorCodes(t) {
+			_, ok := catalog[code]
+			assert.True(t, ok || lang == DefaultLanguage, "%s has no %q", lang, code)
+		}
+		for key := range catalog {
+			if strings.Contains(key, ".") {
+				_, ok := english[key]
+				assert.True(t, ok, "%s has %q the default language lacks", lang, key)
+			}
+		}
+	}
+}
+
+func TestTitles(t *testing.T) {
+	for key, title := range english {
+		if !strings.HasPrefix(key, "title.") {
+			continue
+		}
+		status, err := strconv.Atoi(strings.TrimPrefix(key, "title."))
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, http.StatusText(status), title, key)
+		}
+	}
+}
+
+// errorCodes lists the codes of the errors declared in package shared.
+func errorCodes(t *testing.T) []string {
+	t.Helper()
+	file, err := parser.ParseFile(token.NewFileSet(), "../shared/error.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	codes := []string{}
+	ast.Inspect(file, func(n ast.Node) bool {
+		var code ast.Expr
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			if fun, ok := n.Fun.(*ast.Ident); ok && fun.Name == "NewCustomError" && len(n.Args) == 3 {
+				code = n.Args[1]
+			}
+		case *ast.KeyValueExpr:
+			if key, ok := n.Key.(*ast.Ident); ok && key.Name == "Code" {
+				code = n.Value
+			}
+		}
+		if lit, ok := code.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			codes = append(codes, value)
+		}
+		return true
+	})
+	if len(codes) == 0 {
+		t.Fatal("no error codes found in shared")
+	}
+
+	return codes
+}
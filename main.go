@@ -1,7 +1,24 @@
 package main
 
-import "github.com/adityatresnobudi/job-portal/router"
+import (
+	"log"
+	"os"
+
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/router"
+)
 
 func main() {
-	router.Serve()
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "migrate" {
+		migrate(args[1:])
+		return
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		log.Fatalf("load config: %s\n", err)
+	}
+
+	router.Serve(cfg)
 }
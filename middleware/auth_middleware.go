@@ -1,16 +1,23 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
 	"os"
 	"strings"
 
+	"github.com/adityatresnobudi/job-portal/auth"
 	"github.com/adityatresnobudi/job-portal/helper"
 	"github.com/adityatresnobudi/job-portal/shared"
 	"github.com/gin-gonic/gin"
 )
 
-func Auth() gin.HandlerFunc {
+// RevocationChecker reports whether an access token, identified by its jti,
+// was revoked before it expired or its user was deleted.
+type RevocationChecker interface {
+	IsTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error)
+}
+
+func Auth(tokens *helper.JWT, revocations RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if os.Getenv("ENV_MODE") == "testing" {
 			c.Next()
@@ -20,23 +27,40 @@ func Auth() gin.HandlerFunc {
 		header := c.GetHeader("Authorization")
 		splittedHeader := strings.Split(header, " ")
 		if len(splittedHeader) != 2 {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, shared.ErrInvalidAuthHeader.ToErrorDTO())
+			abortWithError(c, shared.ErrInvalidAuthHeader)
 			return
 		}
 
-		token, err := helper.ValidateJWT(splittedHeader[1])
+		token, err := tokens.ValidateJWT(splittedHeader[1])
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, shared.ErrInvalidToken.ToErrorDTO())
+			abortWithError(c, shared.ErrInvalidToken)
 			return
 		}
 
 		claims, ok := token.Claims.(*helper.JWTClaims)
-		if !ok || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, shared.ErrInvalidToken.ToErrorDTO())
+		if !ok || !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
+			abortWithError(c, shared.ErrInvalidToken)
+			return
+		}
+
+		revoked, err := revocations.IsTokenRevoked(c.Request.Context(), claims.ID, claims.UserId)
+		if err != nil {
+			abortWithError(c, shared.ErrVerifyingToken)
+			return
+		}
+		if revoked {
+			abortWithError(c, shared.ErrTokenRevoked)
 			return
 		}
 
-		c.Set("id", claims.UserId)
+		c.Set(auth.PrincipalKey, auth.Principal{
+			UserId:         claims.UserId,
+			Role:           claims.Role,
+			Permissions:    claims.Permissions,
+			EmailVerified:  claims.EmailVerified,
+			TokenId:        claims.ID,
+			TokenExpiresAt: claims.ExpiresAt.Time,
+		})
 
 		c.Next()
 	}
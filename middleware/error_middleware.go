@@ -1,10 +1,13 @@
 package middleware
 
 import (
-	"net/http"
+	"strconv"
 
+	"github.com/adityatresnobudi/job-portal/i18n"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
 )
 
 func GlobalErrorMiddleware() gin.HandlerFunc {
@@ -15,13 +18,49 @@ func GlobalErrorMiddleware() gin.HandlerFunc {
 		if err != nil {
 			switch e := err.Err.(type) {
 			case *shared.CustomError:
-				c.AbortWithStatusJSON(e.StatusCode, e.ToErrorDTO())
+				abortWithProblem(c, e.ToProblem(requestid.Get(c)))
+			case *shared.ValidationError:
+				abortWithProblem(c, e.ToProblem(requestid.Get(c)))
 			default:
-				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
-					"message": err.Error(),
-				})
+				// Unknown errors may carry SQL, paths or other internals; the
+				// logger middleware records them, the client only sees the id.
+				abortWithProblem(c, shared.ErrInternal.ToProblem(requestid.Get(c)))
 			}
-			c.Abort()
 		}
 	}
 }
+
+// abortWithError stops the chain and responds with err as problem details.
+func abortWithError(c *gin.Context, err *shared.CustomError) {
+	abortWithProblem(c, err.ToProblem(requestid.Get(c)))
+}
+
+func abortWithProblem(c *gin.Context, problem shared.Problem) {
+	localizeProblem(&problem, c.GetString(i18n.LanguageKey))
+	c.Abort()
+	c.Header("Content-Type", shared.ProblemContentType)
+	c.Render(problem.Status, render.JSON{Data: problem})
+}
+
+// localizeProblem translates the title, detail and field messages of
+// problem. The English messages of the errors stay when lang has no entry for
+// them.
+func localizeProblem(problem *shared.Problem, lang string) {
+	if title, ok := i18n.Lookup(lang, "title."+strconv.Itoa(problem.Status)); ok {
+		problem.Title = title
+	}
+	if detail, ok := i18n.Lookup(lang, problem.Code); ok {
+		problem.Detail = detail
+	}
+
+	fields := make([]shared.FieldError, len(problem.Errors))
+	for i, f := range problem.Errors {
+		if _, ok := i18n.Lookup(lang, "field."+f.Code); ok {
+			f.Message = i18n.Translate(lang, "field."+f.Code, append([]interface{}{f.Field}, f.Args...)...)
+		}
+		fields[i] = f
+	}
+	if len(fields) > 0 {
+		problem.Errors = fields
+	}
+}
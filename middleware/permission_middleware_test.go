@@ -0,0 +1,76 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/middleware"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		expected  int
+	}{
+		{name: "poster may write jobs", principal: &auth.Principal{UserId: 1, Role: auth.RolePoster, Permissions: auth.PermissionsOf(auth.RolePoster)}, expected: http.StatusOK},
+		{name: "seeker may not write jobs", principal: &auth.Principal{UserId: 1, Role: auth.RoleSeeker, Permissions: auth.PermissionsOf(auth.RoleSeeker)}, expected: http.StatusForbidden},
+		{name: "anonymous caller is rejected", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if tt.principal != nil {
+					c.Set(auth.PrincipalKey, *tt.principal)
+				}
+			}, middleware.RequirePermission(auth.PermissionJobsWrite), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal *auth.Principal
+		expected  int
+	}{
+		{name: "verified user may pass", principal: &auth.Principal{UserId: 1, EmailVerified: true}, expected: http.StatusOK},
+		{name: "unverified user is forbidden", principal: &auth.Principal{UserId: 1}, expected: http.StatusForbidden},
+		{name: "anonymous caller is rejected", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.GET("/", func(c *gin.Context) {
+				if tt.principal != nil {
+					c.Set(auth.PrincipalKey, *tt.principal)
+				}
+			}, middleware.RequireVerifiedEmail(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/", nil)
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
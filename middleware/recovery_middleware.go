@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery answers a request whose handler panicked with the internal error
+// problem. The panic is recorded on the context for the logger middleware.
+func Recovery() gin.HandlerFunc {
+	return gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		c.Error(fmt.Errorf("panic: %v", recovered))
+		abortWithError(c, shared.ErrInternal)
+	})
+}
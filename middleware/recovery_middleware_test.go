@@ -0,0 +1,35 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/middleware"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(requestid.New(), middleware.Language(), middleware.Recovery(), middleware.GlobalErrorMiddleware())
+	router.GET("/", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	rec := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/", nil)
+	req.Header.Set("X-Request-ID", "request-1")
+	router.ServeHTTP(rec, req)
+
+	var problem shared.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, shared.ProblemContentType, rec.Header().Get("Content-Type"))
+	assert.Equal(t, shared.ErrInternal.ToProblem("request-1"), problem)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/db"
+	"github.com/adityatresnobudi/job-portal/migration"
+)
+
+const migrateUsage = `usage: job-portal migrate <command> [config flags]
+
+commands:
+  up          apply every pending migration
+  down [n]    revert the latest n migrations (default 1)
+  status      list migrations and when they were applied
+  unlock      release the lock of a migration process that died`
+
+func migrate(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	steps := 1
+	if command == "down" && len(args) > 0 {
+		if n, err := strconv.Atoi(args[0]); err == nil {
+			if n < 1 {
+				log.Fatalf("migrate down: n must be at least 1\n")
+			}
+			steps, args = n, args[1:]
+		}
+	}
+
+	cfg, err := config.Load(args)
+	if err != nil {
+		log.Fatalf("load config: %s\n", err)
+	}
+
+	conn, err := db.Connect(cfg.Database)
+	if err != nil {
+		log.Fatalf("connect database: %s\n", err)
+	}
+
+	migrations, err := migration.Embedded()
+	if err != nil {
+		log.Fatalf("load migrations: %s\n", err)
+	}
+
+	ctx := context.Background()
+	m := migration.NewMigrator(conn, migrations)
+
+	switch command {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mg := range applied {
+			log.Printf("applied %04d_%s\n", mg.Version, mg.Name)
+		}
+		if err != nil {
+			log.Fatalf("migrate up: %s\n", err)
+		}
+		log.Printf("%d migration(s) applied\n", len(applied))
+	case "down":
+		reverted, err := m.Down(ctx, steps)
+		for _, mg := range reverted {
+			log.Printf("reverted %04d_%s\n", mg.Version, mg.Name)
+		}
+		if err != nil {
+			log.Fatalf("migrate down: %s\n", err)
+		}
+		log.Printf("%d migration(s) reverted\n", len(reverted))
+	case "status":
+		statuses, err := m.Status(ctx)
+		if err != nil {
+			log.Fatalf("migrate status: %s\n", err)
+		}
+		for _, s := range statuses {
+			appliedAt := "pending"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, appliedAt)
+		}
+	case "unlock":
+		if err := m.Unlock(ctx); err != nil {
+			log.Fatalf("migrate unlock: %s\n", err)
+		}
+		log.Println("migration lock released")
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,320 @@
+// Package migration applies the versioned SQL files in sql/ to the database.
+//
+// Files are named <version>_<name>.up.sql and <version>_<name>.down.sql. The
+// checksum of every applied up file is recorded so an edited migration is
+// caught instead of silently diverging from what production ran.
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed sql/*.sql
+var files embed.FS
+
+var (
+	ErrLocked           = errors.New("migration: another migration holds the lock")
+	ErrChecksumMismatch = errors.New("migration: applied migration was modified")
+	ErrUnknownVersion   = errors.New("migration: database has a version with no migration file")
+)
+
+var fileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// superseded lists earlier checksums of migrations that were rewritten
+// without changing the schema they produce, so databases that ran an
+// earlier version still verify. 0001 to 0003 were made to adopt tables
+// created by hand before there were migrations.
+var superseded = map[uint][]string{
+	1: {"73a9d69c564fb452d4fffe2822e5a189a65a48c06471ee13d86bccf74b84c5ab"},
+	2: {"1cff952d6652ac3b3982f22c5477e82698f22920817b26ac862fd4b6fb76cf75"},
+	3: {"2d12c31f6f22523fc97108e0fb1a6ca9e196a2658babf84a7d4f9f9392de466f"},
+}
+
+type Migration struct {
+	Version  uint
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Status struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// Embedded returns the migrations shipped with the binary.
+func Embedded() ([]Migration, error) {
+	sub, err := fs.Sub(files, "sql")
+	if err != nil {
+		return nil, err
+	}
+	return Load(sub)
+}
+
+// Load parses every migration file at the root of fsys, sorted by version.
+// Each version needs both an up and a down file.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: match[2]}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration: version %d has two names, %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration: version %d needs both an up and a down file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type appliedMigration struct {
+	Version   uint      `gorm:"column:version"`
+	Name      string    `gorm:"column:name"`
+	Checksum  string    `gorm:"column:checksum"`
+	AppliedAt time.Time `gorm:"column:applied_at"`
+}
+
+func (appliedMigration) TableName() string {
+	return "schema_migrations"
+}
+
+const bootstrap = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version    BIGINT PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    checksum   CHAR(64)     NOT NULL,
+    applied_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+CREATE TABLE IF NOT EXISTS schema_migrations_lock (
+    id        INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    locked_by VARCHAR(255) NOT NULL,
+    locked_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);`
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+	owner      string
+}
+
+func NewMigrator(db *gorm.DB, migrations []Migration) *Migrator {
+	host, _ := os.Hostname()
+	return &Migrator{
+		db:         db,
+		migrations: migrations,
+		owner:      fmt.Sprintf("%s:%d", host, os.Getpid()),
+	}
+}
+
+// Up applies every pending migration in version order, each in its own
+// transaction, and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(applied map[uint]appliedMigration) error {
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+
+			err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Up).Error; err != nil {
+					return err
+				}
+				return tx.Create(&appliedMigration{
+					Version:   migration.Version,
+					Name:      migration.Name,
+					Checksum:  migration.Checksum,
+					AppliedAt: time.Now(),
+				}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration: applying %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down reverts the latest steps applied migrations, newest first, and returns
+// the ones it reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(applied map[uint]appliedMigration) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+
+			err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return err
+				}
+				return tx.Where("version = ?", migration.Version).Delete(&appliedMigration{}).Error
+			})
+			if err != nil {
+				return fmt.Errorf("migration: reverting %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Status lists every known migration with the time it was applied, if it was.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	if err := m.db.WithContext(ctx).Exec(bootstrap).Error; err != nil {
+		return nil, err
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := Status{Migration: migration}
+		if a, ok := applied[migration.Version]; ok {
+			appliedAt := a.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// Unlock releases a lock left behind by a migration process that died. Only
+// use it once that process is known to be gone.
+func (m *Migrator) Unlock(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec("DELETE FROM schema_migrations_lock").Error
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(applied map[uint]appliedMigration) error) error {
+	db := m.db.WithContext(ctx)
+	if err := db.Exec(bootstrap).Error; err != nil {
+		return err
+	}
+
+	res := db.Exec("INSERT INTO schema_migrations_lock (id, locked_by) VALUES (1, ?) ON CONFLICT DO NOTHING", m.owner)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrLocked
+	}
+	defer db.Exec("DELETE FROM schema_migrations_lock WHERE locked_by = ?", m.owner)
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+	if err := m.verify(applied); err != nil {
+		return err
+	}
+
+	return fn(applied)
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[uint]appliedMigration, error) {
+	rows := []appliedMigration{}
+	if err := m.db.WithContext(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) verify(applied map[uint]appliedMigration) error {
+	known := make(map[uint]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, a := range applied {
+		migration, ok := known[version]
+		if !ok {
+			return fmt.Errorf("%w: %d_%s", ErrUnknownVersion, version, a.Name)
+		}
+		if migration.Checksum != a.Checksum && !isSuperseded(version, a.Checksum) {
+			return fmt.Errorf("%w: %d_%s", ErrChecksumMismatch, version, a.Name)
+		}
+	}
+
+	return nil
+}
+
+func isSuperseded(version uint, checksum string) bool {
+	for _, old := range superseded[version] {
+		if old == checksum {
+			return true
+		}
+	}
+	return false
+}
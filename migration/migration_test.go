@@ -0,0 +1,142 @@
+package migration_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestEmbedded(t *testing.T) {
+	migrations, err := migration.Embedded()
+
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, m := range migrations {
+		assert.Equal(t, uint(i+1), m.Version, "versions must be contiguous")
+		assert.Len(t, m.Checksum, 64)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("should pair up and down files and sort by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"0002_second.up.sql":   {Data: []byte("SELECT 2;")},
+			"0002_second.down.sql": {Data: []byte("SELECT -2;")},
+			"0001_first.up.sql":    {Data: []byte("SELECT 1;")},
+			"0001_first.down.sql":  {Data: []byte("SELECT -1;")},
+			"README.md":            {Data: []byte("ignored")},
+		}
+
+		migrations, err := migration.Load(fsys)
+
+		require.NoError(t, err)
+		require.Len(t, migrations, 2)
+		assert.Equal(t, "first", migrations[0].Name)
+		assert.Equal(t, "SELECT -2;", migrations[1].Down)
+	})
+
+	t.Run("should change the checksum when the up file changes", func(t *testing.T) {
+		before, err := migration.Load(fstest.MapFS{
+			"0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+			"0001_first.down.sql": {Data: []byte("SELECT -1;")},
+		})
+		require.NoError(t, err)
+		after, err := migration.Load(fstest.MapFS{
+			"0001_first.up.sql":   {Data: []byte("SELECT 1 ;")},
+			"0001_first.down.sql": {Data: []byte("SELECT -1;")},
+		})
+		require.NoError(t, err)
+
+		assert.NotEqual(t, before[0].Checksum, after[0].Checksum)
+	})
+
+	t.Run("should reject a migration without a down file", func(t *testing.T) {
+		_, err := migration.Load(fstest.MapFS{
+			"0001_first.up.sql": {Data: []byte("SELECT 1;")},
+		})
+
+		assert.Error(t, err)
+	})
+}
+
+// handMadeSchema is what the first models needed before there were
+// migrations.
+const handMadeSchema = `
+CREATE TABLE users (
+    id BIGSERIAL PRIMARY KEY, user_name TEXT, email TEXT, phone TEXT, user_password TEXT,
+    current_job TEXT, user_age BIGINT, is_job_poster BOOLEAN,
+    created_at TIMESTAMPTZ, updated_at TIMESTAMPTZ, deleted_at TIMESTAMPTZ
+);
+CREATE TABLE jobs (
+    id BIGSERIAL PRIMARY KEY, job_poster_id BIGINT, job_name TEXT, job_desc TEXT, quota BIGINT,
+    is_open BOOLEAN NOT NULL, expiry_date TIMESTAMPTZ,
+    created_at TIMESTAMPTZ, updated_at TIMESTAMPTZ, deleted_at TIMESTAMPTZ
+);
+CREATE TABLE user_jobs (
+    id BIGSERIAL PRIMARY KEY, job_id BIGINT, user_id BIGINT,
+    created_at TIMESTAMPTZ, updated_at TIMESTAMPTZ, deleted_at TIMESTAMPTZ
+);
+INSERT INTO users (user_name, email, user_password, is_job_poster, deleted_at) VALUES ('poster', 'poster@test.local', 'x', TRUE, '0001-01-01');
+INSERT INTO jobs (job_poster_id, job_name, job_desc, quota, is_open, expiry_date, created_at, updated_at) VALUES (1, 'legacy', '', 2, TRUE, NOW() + INTERVAL '1 day', NOW(), NOW());
+INSERT INTO user_jobs (job_id, user_id) VALUES (1, 1);`
+
+func TestMigrator_UpAdoptsHandMadeTables(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	ctx := context.Background()
+
+	admin, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	schema := fmt.Sprintf("adopt_%d", time.Now().UnixNano())
+	require.NoError(t, admin.Exec("CREATE SCHEMA "+schema).Error)
+	t.Cleanup(func() { admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	separator := " "
+	if strings.Contains(dsn, "://") {
+		separator = "&"
+		if !strings.Contains(dsn, "?") {
+			separator = "?"
+		}
+	}
+	db, err := gorm.Open(postgres.Open(dsn+separator+"search_path="+schema), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(handMadeSchema).Error)
+
+	migrations, err := migration.Embedded()
+	require.NoError(t, err)
+	applied, err := migration.NewMigrator(db, migrations).Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(migrations))
+
+	var user struct {
+		Role      string
+		DeletedAt *time.Time
+	}
+	require.NoError(t, db.Raw("SELECT role, deleted_at FROM users WHERE id = 1").Scan(&user).Error)
+	assert.Equal(t, "poster", user.Role)
+	assert.Nil(t, user.DeletedAt)
+
+	var status string
+	require.NoError(t, db.Raw("SELECT status FROM jobs WHERE id = 1").Scan(&status).Error)
+	assert.Equal(t, "published", status)
+	require.NoError(t, db.Raw("SELECT status FROM user_jobs WHERE id = 1").Scan(&status).Error)
+	assert.Equal(t, "applied", status)
+
+	err = db.Exec("INSERT INTO jobs (job_poster_id, organization_id, job_name, expiry_date) VALUES (1, 1, 'new', NOW())").Error
+	assert.NoError(t, err, "is_open must no longer be required")
+	err = db.Exec("INSERT INTO user_jobs (job_id, user_id) VALUES (1, 1)").Error
+	assert.Error(t, err, "an applicant applies to a job once")
+}
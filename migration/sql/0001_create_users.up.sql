@@ -0,0 +1,40 @@
+-- users may predate migrations, created by hand for the first models. Such a
+-- table is adopted: only what it lacks is added, its rows are kept.
+CREATE TABLE IF NOT EXISTS users (
+    id            BIGSERIAL PRIMARY KEY,
+    user_name     VARCHAR(255) NOT NULL,
+    email         VARCHAR(255) NOT NULL,
+    phone         VARCHAR(32)  NOT NULL DEFAULT '',
+    user_password VARCHAR(255) NOT NULL,
+    current_job   VARCHAR(255) NOT NULL DEFAULT '',
+    user_age      INTEGER      NOT NULL DEFAULT 0,
+    is_job_poster BOOLEAN      NOT NULL DEFAULT FALSE,
+    role          VARCHAR(16)  NOT NULL DEFAULT 'seeker'
+        CHECK (role IN ('seeker', 'poster', 'admin')),
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    deleted_at    TIMESTAMPTZ
+);
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS phone         VARCHAR(32)  NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS current_job   VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS user_age      INTEGER      NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS is_job_poster BOOLEAN      NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS deleted_at    TIMESTAMPTZ;
+ALTER TABLE users ALTER COLUMN deleted_at DROP NOT NULL;
+
+-- Hand-made tables only knew is_job_poster; their posters keep posting.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'role') THEN
+        ALTER TABLE users ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'seeker'
+            CHECK (role IN ('seeker', 'poster', 'admin'));
+        UPDATE users SET role = 'poster' WHERE is_job_poster;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_status_histories;
+DROP TABLE IF EXISTS jobs;
@@ -0,0 +1,71 @@
+-- jobs may predate migrations like users and is adopted the same way.
+CREATE TABLE IF NOT EXISTS jobs (
+    id            BIGSERIAL PRIMARY KEY,
+    job_poster_id BIGINT       NOT NULL REFERENCES users (id),
+    job_name      VARCHAR(255) NOT NULL,
+    job_desc      TEXT         NOT NULL DEFAULT '',
+    quota         INTEGER      NOT NULL DEFAULT 0 CHECK (quota >= 0),
+    status        VARCHAR(16)  NOT NULL DEFAULT 'draft'
+        CHECK (status IN ('draft', 'published', 'paused', 'closed', 'archived')),
+    expiry_date   TIMESTAMPTZ  NOT NULL,
+    created_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    deleted_at    TIMESTAMPTZ
+);
+
+ALTER TABLE jobs
+    ADD COLUMN IF NOT EXISTS job_desc   TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS quota      INTEGER     NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE jobs ALTER COLUMN deleted_at DROP NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint
+                   WHERE conrelid = 'jobs'::regclass AND conname = 'jobs_job_poster_id_fkey') THEN
+        ALTER TABLE jobs ADD CONSTRAINT jobs_job_poster_id_fkey FOREIGN KEY (job_poster_id) REFERENCES users (id);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint
+                   WHERE conrelid = 'jobs'::regclass AND conname = 'jobs_quota_check') THEN
+        ALTER TABLE jobs ADD CONSTRAINT jobs_quota_check CHECK (quota >= 0);
+    END IF;
+
+    -- Hand-made tables tracked is_open, which status replaces. It is left in
+    -- place but no longer required, as nothing writes it anymore.
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_schema = current_schema() AND table_name = 'jobs' AND column_name = 'status') THEN
+        ALTER TABLE jobs ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'draft'
+            CHECK (status IN ('draft', 'published', 'paused', 'closed', 'archived'));
+        IF EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_schema = current_schema() AND table_name = 'jobs' AND column_name = 'is_open') THEN
+            UPDATE jobs SET status = CASE WHEN is_open THEN 'published' ELSE 'closed' END;
+        END IF;
+    END IF;
+    IF EXISTS (SELECT 1 FROM information_schema.columns
+               WHERE table_schema = current_schema() AND table_name = 'jobs' AND column_name = 'is_open') THEN
+        ALTER TABLE jobs ALTER COLUMN is_open DROP NOT NULL;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_jobs_job_poster_id ON jobs (job_poster_id);
+CREATE INDEX IF NOT EXISTS idx_jobs_status_expiry_date ON jobs (status, expiry_date);
+
+-- Must match repository.jobDocument exactly or full-text search seq scans.
+CREATE INDEX IF NOT EXISTS idx_jobs_document ON jobs USING GIN ((
+    setweight(to_tsvector('english', coalesce(job_name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(job_desc, '')), 'B')
+));
+
+CREATE TABLE IF NOT EXISTS job_status_histories (
+    id          BIGSERIAL PRIMARY KEY,
+    job_id      BIGINT      NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    from_status VARCHAR(16) NOT NULL DEFAULT '',
+    to_status   VARCHAR(16) NOT NULL,
+    changed_by  BIGINT      NOT NULL DEFAULT 0,
+    reason      TEXT        NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_job_status_histories_job_id ON job_status_histories (job_id, created_at);
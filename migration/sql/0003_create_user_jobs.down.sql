@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS application_status_histories;
+DROP TABLE IF EXISTS user_jobs;
@@ -0,0 +1,51 @@
+-- user_jobs may predate migrations like users and is adopted the same way.
+-- Applications from before statuses existed count as applied.
+CREATE TABLE IF NOT EXISTS user_jobs (
+    id         BIGSERIAL PRIMARY KEY,
+    job_id     BIGINT      NOT NULL REFERENCES jobs (id),
+    user_id    BIGINT      NOT NULL REFERENCES users (id),
+    status     VARCHAR(16) NOT NULL DEFAULT 'applied'
+        CHECK (status IN ('applied', 'screening', 'interview', 'offered', 'hired', 'rejected', 'withdrawn')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+ALTER TABLE user_jobs
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+ALTER TABLE user_jobs ALTER COLUMN deleted_at DROP NOT NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint
+                   WHERE conrelid = 'user_jobs'::regclass AND conname = 'user_jobs_job_id_fkey') THEN
+        ALTER TABLE user_jobs ADD CONSTRAINT user_jobs_job_id_fkey FOREIGN KEY (job_id) REFERENCES jobs (id);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint
+                   WHERE conrelid = 'user_jobs'::regclass AND conname = 'user_jobs_user_id_fkey') THEN
+        ALTER TABLE user_jobs ADD CONSTRAINT user_jobs_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns
+                   WHERE table_schema = current_schema() AND table_name = 'user_jobs' AND column_name = 'status') THEN
+        ALTER TABLE user_jobs ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'applied'
+            CHECK (status IN ('applied', 'screening', 'interview', 'offered', 'hired', 'rejected', 'withdrawn'));
+    END IF;
+END $$;
+
+-- A withdrawn (soft-deleted) application does not block applying again.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_jobs_user_id_job_id ON user_jobs (user_id, job_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_user_jobs_job_id ON user_jobs (job_id, created_at);
+
+CREATE TABLE IF NOT EXISTS application_status_histories (
+    id             BIGSERIAL PRIMARY KEY,
+    application_id BIGINT      NOT NULL REFERENCES user_jobs (id) ON DELETE CASCADE,
+    from_status    VARCHAR(16) NOT NULL DEFAULT '',
+    to_status      VARCHAR(16) NOT NULL,
+    changed_by     BIGINT      NOT NULL DEFAULT 0,
+    note           TEXT        NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_application_status_histories_application_id ON application_status_histories (application_id, created_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_user_jobs_deleted_at;
+DROP INDEX IF EXISTS idx_jobs_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+
+DROP INDEX idx_users_email;
+CREATE UNIQUE INDEX idx_users_email ON users (email);
@@ -0,0 +1,14 @@
+-- Rows written while deleted_at was a plain time.Time carry the zero time
+-- instead of NULL and would read as deleted.
+UPDATE users SET deleted_at = NULL WHERE deleted_at < '0002-01-01';
+UPDATE jobs SET deleted_at = NULL WHERE deleted_at < '0002-01-01';
+UPDATE user_jobs SET deleted_at = NULL WHERE deleted_at < '0002-01-01';
+
+-- A deleted account must not keep its email from being registered again.
+DROP INDEX idx_users_email;
+CREATE UNIQUE INDEX idx_users_email ON users (email) WHERE deleted_at IS NULL;
+
+-- Used by the admin trash listings and the purge job.
+CREATE INDEX idx_users_deleted_at ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_jobs_deleted_at ON jobs (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_user_jobs_deleted_at ON user_jobs (deleted_at) WHERE deleted_at IS NOT NULL;
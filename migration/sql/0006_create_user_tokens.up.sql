@@ -0,0 +1,13 @@
+CREATE TABLE user_tokens (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    BIGINT       NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    purpose    VARCHAR(32)  NOT NULL,
+    token_hash CHAR(64)     NOT NULL,
+    email      VARCHAR(255) NOT NULL DEFAULT '',
+    expires_at TIMESTAMPTZ  NOT NULL,
+    used_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_user_tokens_token_hash ON user_tokens (token_hash);
+CREATE INDEX idx_user_tokens_user_id_purpose ON user_tokens (user_id, purpose) WHERE used_at IS NULL;
@@ -0,0 +1,3 @@
+-- Bumped on every write to a job; clients send it back in If-Match so an
+-- edit based on a stale copy is refused instead of overwriting newer data.
+ALTER TABLE jobs ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
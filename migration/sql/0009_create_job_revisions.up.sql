@@ -0,0 +1,23 @@
+-- One row per version of a job's posting, never updated. A revision holds
+-- the posting as it was after the change; what changed is the difference to
+-- the revision before it.
+CREATE TABLE job_revisions (
+    id          BIGSERIAL PRIMARY KEY,
+    job_id      BIGINT       NOT NULL REFERENCES jobs (id) ON DELETE CASCADE,
+    version     INTEGER      NOT NULL,
+    changed_by  BIGINT       NOT NULL DEFAULT 0,
+    reason      VARCHAR(32)  NOT NULL,
+    job_name    VARCHAR(255) NOT NULL,
+    job_desc    TEXT         NOT NULL,
+    quota       INTEGER      NOT NULL,
+    status      VARCHAR(16)  NOT NULL,
+    expiry_date TIMESTAMPTZ  NOT NULL,
+    created_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_job_revisions_job_id_version ON job_revisions (job_id, version);
+
+-- Jobs created before revisions existed start from how they look now.
+INSERT INTO job_revisions (job_id, version, changed_by, reason, job_name, job_desc, quota, status, expiry_date, created_at)
+SELECT id, version, 0, 'baseline', job_name, job_desc, quota, status, expiry_date, updated_at
+FROM jobs;
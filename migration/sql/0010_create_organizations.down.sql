@@ -0,0 +1,3 @@
+ALTER TABLE jobs DROP COLUMN IF EXISTS organization_id;
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
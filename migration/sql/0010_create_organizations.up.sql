@@ -0,0 +1,40 @@
+CREATE TABLE organizations (
+    id         BIGSERIAL PRIMARY KEY,
+    name       VARCHAR(255) NOT NULL,
+    created_by BIGINT       NOT NULL REFERENCES users (id),
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE organization_members (
+    id              BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT      NOT NULL REFERENCES organizations (id) ON DELETE CASCADE,
+    user_id         BIGINT      NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    role            VARCHAR(16) NOT NULL CHECK (role IN ('owner', 'admin', 'recruiter')),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_organization_members_organization_id_user_id ON organization_members (organization_id, user_id);
+CREATE INDEX idx_organization_members_user_id ON organization_members (user_id);
+
+-- Jobs belong to an organization now; job_poster_id keeps the recruiter who
+-- created the job.
+ALTER TABLE jobs ADD COLUMN organization_id BIGINT REFERENCES organizations (id);
+
+-- Every poster with jobs gets an organization of their own that takes over
+-- those jobs, so nobody loses access to what they posted.
+INSERT INTO organizations (name, created_by)
+SELECT u.user_name, u.id
+FROM users u
+WHERE EXISTS (SELECT 1 FROM jobs j WHERE j.job_poster_id = u.id);
+
+INSERT INTO organization_members (organization_id, user_id, role)
+SELECT id, created_by, 'owner' FROM organizations;
+
+UPDATE jobs SET organization_id = o.id
+FROM organizations o
+WHERE o.created_by = jobs.job_poster_id;
+
+ALTER TABLE jobs ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX idx_jobs_organization_id ON jobs (organization_id);
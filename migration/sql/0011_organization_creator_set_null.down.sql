@@ -0,0 +1,5 @@
+-- created_by stays nullable: organizations whose creator was purged have no
+-- one to point at.
+ALTER TABLE organizations DROP CONSTRAINT organizations_created_by_fkey;
+ALTER TABLE organizations
+    ADD CONSTRAINT organizations_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id);
@@ -0,0 +1,6 @@
+-- Purging a deleted user must not be blocked by the organizations they
+-- created; the organization stays and forgets its creator.
+ALTER TABLE organizations ALTER COLUMN created_by DROP NOT NULL;
+ALTER TABLE organizations DROP CONSTRAINT organizations_created_by_fkey;
+ALTER TABLE organizations
+    ADD CONSTRAINT organizations_created_by_fkey FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL;
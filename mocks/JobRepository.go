@@ -5,9 +5,11 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/adityatresnobudi/job-portal/model"
+	dto "github.com/adityatresnobudi/job-portal/dto"
 	mock "github.com/stretchr/testify/mock"
 
+	model "github.com/adityatresnobudi/job-portal/model"
+
 	time "time"
 )
 
@@ -16,20 +18,66 @@ type JobRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, newJob
-func (_m *JobRepository) Create(ctx context.Context, newJob model.Jobs) (model.Jobs, error) {
-	ret := _m.Called(ctx, newJob)
+// CloseExpired provides a mock function with given fields: ctx
+func (_m *JobRepository) CloseExpired(ctx context.Context) ([]model.Jobs, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Jobs); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Jobs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CloseFilled provides a mock function with given fields: ctx
+func (_m *JobRepository) CloseFilled(ctx context.Context) ([]model.Jobs, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Jobs); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Jobs)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, newJob, createdBy
+func (_m *JobRepository) Create(ctx context.Context, newJob model.Jobs, createdBy uint) (model.Jobs, error) {
+	ret := _m.Called(ctx, newJob, createdBy)
 
 	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs) model.Jobs); ok {
-		r0 = rf(ctx, newJob)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs, uint) model.Jobs); ok {
+		r0 = rf(ctx, newJob, createdBy)
 	} else {
 		r0 = ret.Get(0).(model.Jobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs) error); ok {
-		r1 = rf(ctx, newJob)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs, uint) error); ok {
+		r1 = rf(ctx, newJob, createdBy)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,19 +86,105 @@ func (_m *JobRepository) Create(ctx context.Context, newJob model.Jobs) (model.J
 }
 
 // Delete provides a mock function with given fields: ctx, job
-func (_m *JobRepository) Delete(ctx context.Context, job model.Jobs) (model.Jobs, error) {
+func (_m *JobRepository) Delete(ctx context.Context, job model.Jobs) error {
 	ret := _m.Called(ctx, job)
 
-	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs) model.Jobs); ok {
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs) error); ok {
 		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, query
+func (_m *JobRepository) FindAll(ctx context.Context, query dto.JobsQuery) ([]model.Jobs, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context, dto.JobsQuery) []model.Jobs); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Jobs)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, dto.JobsQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.JobsQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindAnyById provides a mock function with given fields: ctx, jobId
+func (_m *JobRepository) FindAnyById(ctx context.Context, jobId int) (model.Jobs, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.Jobs); ok {
+		r0 = rf(ctx, jobId)
+	} else {
+		r0 = ret.Get(0).(model.Jobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindById provides a mock function with given fields: ctx, jobId
+func (_m *JobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.Jobs); ok {
+		r0 = rf(ctx, jobId)
+	} else {
+		r0 = ret.Get(0).(model.Jobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByIdWithPoster provides a mock function with given fields: ctx, jobId
+func (_m *JobRepository) FindByIdWithPoster(ctx context.Context, jobId int) (model.Jobs, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 model.Jobs
+	if rf, ok := ret.Get(0).(func(context.Context, int) model.Jobs); ok {
+		r0 = rf(ctx, jobId)
 	} else {
 		r0 = ret.Get(0).(model.Jobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs) error); ok {
-		r1 = rf(ctx, job)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,22 +192,50 @@ func (_m *JobRepository) Delete(ctx context.Context, job model.Jobs) (model.Jobs
 	return r0, r1
 }
 
-// FindAll provides a mock function with given fields: ctx, name
-func (_m *JobRepository) FindAll(ctx context.Context, name string) ([]model.Jobs, error) {
-	ret := _m.Called(ctx, name)
+// FindDeleted provides a mock function with given fields: ctx, query
+func (_m *JobRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Jobs, int64, error) {
+	ret := _m.Called(ctx, query)
 
 	var r0 []model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, string) []model.Jobs); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []model.Jobs); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Jobs)
 		}
 	}
 
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindPreviousRevision provides a mock function with given fields: ctx, jobId, version
+func (_m *JobRepository) FindPreviousRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error) {
+	ret := _m.Called(ctx, jobId, version)
+
+	var r0 model.JobRevisions
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) model.JobRevisions); ok {
+		r0 = rf(ctx, jobId, version)
+	} else {
+		r0 = ret.Get(0).(model.JobRevisions)
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, jobId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -81,8 +243,103 @@ func (_m *JobRepository) FindAll(ctx context.Context, name string) ([]model.Jobs
 	return r0, r1
 }
 
-// FindById provides a mock function with given fields: ctx, jobId
-func (_m *JobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, error) {
+// FindRevision provides a mock function with given fields: ctx, jobId, version
+func (_m *JobRepository) FindRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error) {
+	ret := _m.Called(ctx, jobId, version)
+
+	var r0 model.JobRevisions
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) model.JobRevisions); ok {
+		r0 = rf(ctx, jobId, version)
+	} else {
+		r0 = ret.Get(0).(model.JobRevisions)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, jobId, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRevisions provides a mock function with given fields: ctx, jobId, query
+func (_m *JobRepository) FindRevisions(ctx context.Context, jobId int, query dto.PaginationQuery) ([]model.JobRevisions, int64, error) {
+	ret := _m.Called(ctx, jobId, query)
+
+	var r0 []model.JobRevisions
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.PaginationQuery) []model.JobRevisions); ok {
+		r0 = rf(ctx, jobId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.JobRevisions)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.PaginationQuery) int64); ok {
+		r1 = rf(ctx, jobId, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, jobId, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindStatusHistory provides a mock function with given fields: ctx, jobId
+func (_m *JobRepository) FindStatusHistory(ctx context.Context, jobId int) ([]model.JobStatusHistories, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 []model.JobStatusHistories
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.JobStatusHistories); ok {
+		r0 = rf(ctx, jobId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.JobStatusHistories)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *JobRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, jobId
+func (_m *JobRepository) Restore(ctx context.Context, jobId int) (model.Jobs, error) {
 	ret := _m.Called(ctx, jobId)
 
 	var r0 model.Jobs
@@ -102,20 +359,20 @@ func (_m *JobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, e
 	return r0, r1
 }
 
-// UpdateExpDate provides a mock function with given fields: ctx, job, expDate
-func (_m *JobRepository) UpdateExpDate(ctx context.Context, job model.Jobs, expDate time.Time) (model.Jobs, error) {
-	ret := _m.Called(ctx, job, expDate)
+// Update provides a mock function with given fields: ctx, job, fields, changedBy, versions
+func (_m *JobRepository) Update(ctx context.Context, job model.Jobs, fields map[string]interface{}, changedBy uint, versions []int) (model.Jobs, error) {
+	ret := _m.Called(ctx, job, fields, changedBy, versions)
 
 	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs, time.Time) model.Jobs); ok {
-		r0 = rf(ctx, job, expDate)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs, map[string]interface{}, uint, []int) model.Jobs); ok {
+		r0 = rf(ctx, job, fields, changedBy, versions)
 	} else {
 		r0 = ret.Get(0).(model.Jobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs, time.Time) error); ok {
-		r1 = rf(ctx, job, expDate)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs, map[string]interface{}, uint, []int) error); ok {
+		r1 = rf(ctx, job, fields, changedBy, versions)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,20 +380,20 @@ func (_m *JobRepository) UpdateExpDate(ctx context.Context, job model.Jobs, expD
 	return r0, r1
 }
 
-// UpdateQuota provides a mock function with given fields: ctx, job, quota
-func (_m *JobRepository) UpdateQuota(ctx context.Context, job model.Jobs, quota int) (model.Jobs, error) {
-	ret := _m.Called(ctx, job, quota)
+// UpdateStatus provides a mock function with given fields: ctx, job, status, changedBy, reason
+func (_m *JobRepository) UpdateStatus(ctx context.Context, job model.Jobs, status string, changedBy uint, reason string) (model.Jobs, error) {
+	ret := _m.Called(ctx, job, status, changedBy, reason)
 
 	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs, int) model.Jobs); ok {
-		r0 = rf(ctx, job, quota)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs, string, uint, string) model.Jobs); ok {
+		r0 = rf(ctx, job, status, changedBy, reason)
 	} else {
 		r0 = ret.Get(0).(model.Jobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs, int) error); ok {
-		r1 = rf(ctx, job, quota)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs, string, uint, string) error); ok {
+		r1 = rf(ctx, job, status, changedBy, reason)
 	} else {
 		r1 = ret.Error(1)
 	}
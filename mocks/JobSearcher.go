@@ -0,0 +1,62 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/adityatresnobudi/job-portal/dto"
+	mock "github.com/stretchr/testify/mock"
+
+	repository "github.com/adityatresnobudi/job-portal/repository"
+)
+
+// JobSearcher is an autogenerated mock type for the JobSearcher type
+type JobSearcher struct {
+	mock.Mock
+}
+
+// Search provides a mock function with given fields: ctx, query
+func (_m *JobSearcher) Search(ctx context.Context, query dto.JobSearchQuery) ([]repository.JobSearchHit, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []repository.JobSearchHit
+	if rf, ok := ret.Get(0).(func(context.Context, dto.JobSearchQuery) []repository.JobSearchHit); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]repository.JobSearchHit)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, dto.JobSearchQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.JobSearchQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewJobSearcher interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewJobSearcher creates a new instance of JobSearcher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewJobSearcher(t mockConstructorTestingTNewJobSearcher) *JobSearcher {
+	mock := &JobSearcher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	auth "github.com/adityatresnobudi/job-portal/auth"
+
 	dto "github.com/adityatresnobudi/job-portal/dto"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,20 +17,20 @@ type JobUsecase struct {
 	mock.Mock
 }
 
-// CloseJob provides a mock function with given fields: ctx, closeJob, jobPosterId
-func (_m *JobUsecase) CloseJob(ctx context.Context, closeJob dto.CloseJobsResponse, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	ret := _m.Called(ctx, closeJob, jobPosterId)
+// ChangeJobStatus provides a mock function with given fields: ctx, jobId, action, principal
+func (_m *JobUsecase) ChangeJobStatus(ctx context.Context, jobId int, action string, principal auth.Principal) (dto.CloseJobsResponse, error) {
+	ret := _m.Called(ctx, jobId, action, principal)
 
 	var r0 dto.CloseJobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, dto.CloseJobsResponse, uint) dto.CloseJobsResponse); ok {
-		r0 = rf(ctx, closeJob, jobPosterId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, string, auth.Principal) dto.CloseJobsResponse); ok {
+		r0 = rf(ctx, jobId, action, principal)
 	} else {
 		r0 = ret.Get(0).(dto.CloseJobsResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, dto.CloseJobsResponse, uint) error); ok {
-		r1 = rf(ctx, closeJob, jobPosterId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, string, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, action, principal)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,20 +38,20 @@ func (_m *JobUsecase) CloseJob(ctx context.Context, closeJob dto.CloseJobsRespon
 	return r0, r1
 }
 
-// CreateJobs provides a mock function with given fields: ctx, newJob, jobPosterId
-func (_m *JobUsecase) CreateJobs(ctx context.Context, newJob dto.JobsPayload, jobPosterId uint) (dto.JobsResponse, error) {
-	ret := _m.Called(ctx, newJob, jobPosterId)
+// CreateJobs provides a mock function with given fields: ctx, newJob, principal
+func (_m *JobUsecase) CreateJobs(ctx context.Context, newJob dto.JobsPayload, principal auth.Principal) (dto.JobsResponse, error) {
+	ret := _m.Called(ctx, newJob, principal)
 
 	var r0 dto.JobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, dto.JobsPayload, uint) dto.JobsResponse); ok {
-		r0 = rf(ctx, newJob, jobPosterId)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.JobsPayload, auth.Principal) dto.JobsResponse); ok {
+		r0 = rf(ctx, newJob, principal)
 	} else {
 		r0 = ret.Get(0).(dto.JobsResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, dto.JobsPayload, uint) error); ok {
-		r1 = rf(ctx, newJob, jobPosterId)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.JobsPayload, auth.Principal) error); ok {
+		r1 = rf(ctx, newJob, principal)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,22 +59,168 @@ func (_m *JobUsecase) CreateJobs(ctx context.Context, newJob dto.JobsPayload, jo
 	return r0, r1
 }
 
-// GetAvailableJobs provides a mock function with given fields: ctx, name
-func (_m *JobUsecase) GetAvailableJobs(ctx context.Context, name string) ([]dto.JobsDTO, error) {
-	ret := _m.Called(ctx, name)
+// DeleteJob provides a mock function with given fields: ctx, jobId, principal
+func (_m *JobUsecase) DeleteJob(ctx context.Context, jobId int, principal auth.Principal) error {
+	ret := _m.Called(ctx, jobId, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) error); ok {
+		r0 = rf(ctx, jobId, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DiffJobRevisions provides a mock function with given fields: ctx, jobId, query, principal
+func (_m *JobUsecase) DiffJobRevisions(ctx context.Context, jobId int, query dto.JobRevisionDiffQuery, principal auth.Principal) (dto.JobRevisionDiffDTO, error) {
+	ret := _m.Called(ctx, jobId, query, principal)
+
+	var r0 dto.JobRevisionDiffDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.JobRevisionDiffQuery, auth.Principal) dto.JobRevisionDiffDTO); ok {
+		r0 = rf(ctx, jobId, query, principal)
+	} else {
+		r0 = ret.Get(0).(dto.JobRevisionDiffDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.JobRevisionDiffQuery, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, query, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAvailableJobs provides a mock function with given fields: ctx, query
+func (_m *JobUsecase) GetAvailableJobs(ctx context.Context, query dto.JobsQuery) ([]dto.JobsDTO, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query)
 
 	var r0 []dto.JobsDTO
-	if rf, ok := ret.Get(0).(func(context.Context, string) []dto.JobsDTO); ok {
-		r0 = rf(ctx, name)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.JobsQuery) []dto.JobsDTO); ok {
+		r0 = rf(ctx, query)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dto.JobsDTO)
 		}
 	}
 
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, dto.JobsQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.JobsQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDeletedJobs provides a mock function with given fields: ctx, query
+func (_m *JobUsecase) GetDeletedJobs(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedJobDTO, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []dto.DeletedJobDTO
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []dto.DeletedJobDTO); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DeletedJobDTO)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetJobDetail provides a mock function with given fields: ctx, jobId
+func (_m *JobUsecase) GetJobDetail(ctx context.Context, jobId int) (dto.JobDetailResponse, error) {
+	ret := _m.Called(ctx, jobId)
+
+	var r0 dto.JobDetailResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int) dto.JobDetailResponse); ok {
+		r0 = rf(ctx, jobId)
+	} else {
+		r0 = ret.Get(0).(dto.JobDetailResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobRevisions provides a mock function with given fields: ctx, jobId, query, principal
+func (_m *JobUsecase) GetJobRevisions(ctx context.Context, jobId int, query dto.PaginationQuery, principal auth.Principal) ([]dto.JobRevisionDTO, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, jobId, query, principal)
+
+	var r0 []dto.JobRevisionDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.PaginationQuery, auth.Principal) []dto.JobRevisionDTO); ok {
+		r0 = rf(ctx, jobId, query, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.JobRevisionDTO)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.PaginationQuery, auth.Principal) dto.PaginationResponse); ok {
+		r1 = rf(ctx, jobId, query, principal)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, dto.PaginationQuery, auth.Principal) error); ok {
+		r2 = rf(ctx, jobId, query, principal)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetJobStatusHistory provides a mock function with given fields: ctx, jobId, principal
+func (_m *JobUsecase) GetJobStatusHistory(ctx context.Context, jobId int, principal auth.Principal) ([]dto.JobStatusHistoryDTO, error) {
+	ret := _m.Called(ctx, jobId, principal)
+
+	var r0 []dto.JobStatusHistoryDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) []dto.JobStatusHistoryDTO); ok {
+		r0 = rf(ctx, jobId, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.JobStatusHistoryDTO)
+		}
+	}
+
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, principal)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,20 +249,20 @@ func (_m *JobUsecase) GetJobsByID(ctx context.Context, jobId int) (dto.CloseJobs
 	return r0, r1
 }
 
-// UpdateExpDate provides a mock function with given fields: ctx, updateJob, expDate, jobPosterId
-func (_m *JobUsecase) UpdateExpDate(ctx context.Context, updateJob dto.CloseJobsResponse, expDate string, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	ret := _m.Called(ctx, updateJob, expDate, jobPosterId)
+// PatchJob provides a mock function with given fields: ctx, jobId, patch, versions, principal
+func (_m *JobUsecase) PatchJob(ctx context.Context, jobId int, patch dto.JobPatch, versions []int, principal auth.Principal) (dto.CloseJobsResponse, error) {
+	ret := _m.Called(ctx, jobId, patch, versions, principal)
 
 	var r0 dto.CloseJobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, dto.CloseJobsResponse, string, uint) dto.CloseJobsResponse); ok {
-		r0 = rf(ctx, updateJob, expDate, jobPosterId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.JobPatch, []int, auth.Principal) dto.CloseJobsResponse); ok {
+		r0 = rf(ctx, jobId, patch, versions, principal)
 	} else {
 		r0 = ret.Get(0).(dto.CloseJobsResponse)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, dto.CloseJobsResponse, string, uint) error); ok {
-		r1 = rf(ctx, updateJob, expDate, jobPosterId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.JobPatch, []int, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, patch, versions, principal)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -121,20 +270,20 @@ func (_m *JobUsecase) UpdateExpDate(ctx context.Context, updateJob dto.CloseJobs
 	return r0, r1
 }
 
-// UpdateQuota provides a mock function with given fields: ctx, updateJob, quota, jobPosterId
-func (_m *JobUsecase) UpdateQuota(ctx context.Context, updateJob dto.CloseJobsResponse, quota int, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	ret := _m.Called(ctx, updateJob, quota, jobPosterId)
+// RestoreJob provides a mock function with given fields: ctx, jobId
+func (_m *JobUsecase) RestoreJob(ctx context.Context, jobId int) (dto.DeletedJobDTO, error) {
+	ret := _m.Called(ctx, jobId)
 
-	var r0 dto.CloseJobsResponse
-	if rf, ok := ret.Get(0).(func(context.Context, dto.CloseJobsResponse, int, uint) dto.CloseJobsResponse); ok {
-		r0 = rf(ctx, updateJob, quota, jobPosterId)
+	var r0 dto.DeletedJobDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int) dto.DeletedJobDTO); ok {
+		r0 = rf(ctx, jobId)
 	} else {
-		r0 = ret.Get(0).(dto.CloseJobsResponse)
+		r0 = ret.Get(0).(dto.DeletedJobDTO)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, dto.CloseJobsResponse, int, uint) error); ok {
-		r1 = rf(ctx, updateJob, quota, jobPosterId)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, jobId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -142,6 +291,36 @@ func (_m *JobUsecase) UpdateQuota(ctx context.Context, updateJob dto.CloseJobsRe
 	return r0, r1
 }
 
+// SearchJobs provides a mock function with given fields: ctx, query
+func (_m *JobUsecase) SearchJobs(ctx context.Context, query dto.JobSearchQuery) ([]dto.JobSearchResult, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []dto.JobSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, dto.JobSearchQuery) []dto.JobSearchResult); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.JobSearchResult)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, dto.JobSearchQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.JobSearchQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 type mockConstructorTestingTNewJobUsecase interface {
 	mock.TestingT
 	Cleanup(func())
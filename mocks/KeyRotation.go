@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// KeyRotation is an autogenerated mock type for the KeyRotation type
+type KeyRotation struct {
+	mock.Mock
+}
+
+// RotateIfDue provides a mock function with given fields: now
+func (_m *KeyRotation) RotateIfDue(now time.Time) (string, error) {
+	ret := _m.Called(now)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(time.Time) string); ok {
+		r0 = rf(now)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewKeyRotation interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewKeyRotation creates a new instance of KeyRotation. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewKeyRotation(t mockConstructorTestingTNewKeyRotation) *KeyRotation {
+	mock := &KeyRotation{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
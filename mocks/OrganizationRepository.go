@@ -0,0 +1,195 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/adityatresnobudi/job-portal/model"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationRepository is an autogenerated mock type for the OrganizationRepository type
+type OrganizationRepository struct {
+	mock.Mock
+}
+
+// AddMember provides a mock function with given fields: ctx, member
+func (_m *OrganizationRepository) AddMember(ctx context.Context, member model.OrganizationMembers) (model.OrganizationMembers, error) {
+	ret := _m.Called(ctx, member)
+
+	var r0 model.OrganizationMembers
+	if rf, ok := ret.Get(0).(func(context.Context, model.OrganizationMembers) model.OrganizationMembers); ok {
+		r0 = rf(ctx, member)
+	} else {
+		r0 = ret.Get(0).(model.OrganizationMembers)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.OrganizationMembers) error); ok {
+		r1 = rf(ctx, member)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, organization, createdBy
+func (_m *OrganizationRepository) Create(ctx context.Context, organization model.Organizations, createdBy uint) (model.Organizations, error) {
+	ret := _m.Called(ctx, organization, createdBy)
+
+	var r0 model.Organizations
+	if rf, ok := ret.Get(0).(func(context.Context, model.Organizations, uint) model.Organizations); ok {
+		r0 = rf(ctx, organization, createdBy)
+	} else {
+		r0 = ret.Get(0).(model.Organizations)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.Organizations, uint) error); ok {
+		r1 = rf(ctx, organization, createdBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindById provides a mock function with given fields: ctx, id
+func (_m *OrganizationRepository) FindById(ctx context.Context, id uint) (model.Organizations, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.Organizations
+	if rf, ok := ret.Get(0).(func(context.Context, uint) model.Organizations); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Organizations)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByMember provides a mock function with given fields: ctx, userId
+func (_m *OrganizationRepository) FindByMember(ctx context.Context, userId uint) ([]model.OrganizationMembers, error) {
+	ret := _m.Called(ctx, userId)
+
+	var r0 []model.OrganizationMembers
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []model.OrganizationMembers); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OrganizationMembers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMembers provides a mock function with given fields: ctx, organizationId
+func (_m *OrganizationRepository) FindMembers(ctx context.Context, organizationId uint) ([]model.OrganizationMembers, error) {
+	ret := _m.Called(ctx, organizationId)
+
+	var r0 []model.OrganizationMembers
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []model.OrganizationMembers); ok {
+		r0 = rf(ctx, organizationId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.OrganizationMembers)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, organizationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindMembership provides a mock function with given fields: ctx, organizationId, userId
+func (_m *OrganizationRepository) FindMembership(ctx context.Context, organizationId uint, userId uint) (model.OrganizationMembers, error) {
+	ret := _m.Called(ctx, organizationId, userId)
+
+	var r0 model.OrganizationMembers
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) model.OrganizationMembers); ok {
+		r0 = rf(ctx, organizationId, userId)
+	} else {
+		r0 = ret.Get(0).(model.OrganizationMembers)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, organizationId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, organizationId, userId
+func (_m *OrganizationRepository) RemoveMember(ctx context.Context, organizationId uint, userId uint) error {
+	ret := _m.Called(ctx, organizationId, userId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, organizationId, userId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, organizationId, userId, role
+func (_m *OrganizationRepository) UpdateMemberRole(ctx context.Context, organizationId uint, userId uint, role string) (model.OrganizationMembers, error) {
+	ret := _m.Called(ctx, organizationId, userId, role)
+
+	var r0 model.OrganizationMembers
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) model.OrganizationMembers); ok {
+		r0 = rf(ctx, organizationId, userId, role)
+	} else {
+		r0 = ret.Get(0).(model.OrganizationMembers)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, organizationId, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewOrganizationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOrganizationRepository creates a new instance of OrganizationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOrganizationRepository(t mockConstructorTestingTNewOrganizationRepository) *OrganizationRepository {
+	mock := &OrganizationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
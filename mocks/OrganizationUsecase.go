@@ -0,0 +1,154 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	auth "github.com/adityatresnobudi/job-portal/auth"
+
+	dto "github.com/adityatresnobudi/job-portal/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OrganizationUsecase is an autogenerated mock type for the OrganizationUsecase type
+type OrganizationUsecase struct {
+	mock.Mock
+}
+
+// AddMember provides a mock function with given fields: ctx, id, payload, principal
+func (_m *OrganizationUsecase) AddMember(ctx context.Context, id int, payload dto.OrganizationMemberPayload, principal auth.Principal) (dto.OrganizationMemberDTO, error) {
+	ret := _m.Called(ctx, id, payload, principal)
+
+	var r0 dto.OrganizationMemberDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.OrganizationMemberPayload, auth.Principal) dto.OrganizationMemberDTO); ok {
+		r0 = rf(ctx, id, payload, principal)
+	} else {
+		r0 = ret.Get(0).(dto.OrganizationMemberDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.OrganizationMemberPayload, auth.Principal) error); ok {
+		r1 = rf(ctx, id, payload, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeMemberRole provides a mock function with given fields: ctx, id, userId, payload, principal
+func (_m *OrganizationUsecase) ChangeMemberRole(ctx context.Context, id int, userId int, payload dto.OrganizationRolePayload, principal auth.Principal) (dto.OrganizationMemberDTO, error) {
+	ret := _m.Called(ctx, id, userId, payload, principal)
+
+	var r0 dto.OrganizationMemberDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, dto.OrganizationRolePayload, auth.Principal) dto.OrganizationMemberDTO); ok {
+		r0 = rf(ctx, id, userId, payload, principal)
+	} else {
+		r0 = ret.Get(0).(dto.OrganizationMemberDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, dto.OrganizationRolePayload, auth.Principal) error); ok {
+		r1 = rf(ctx, id, userId, payload, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrganization provides a mock function with given fields: ctx, payload, principal
+func (_m *OrganizationUsecase) CreateOrganization(ctx context.Context, payload dto.OrganizationPayload, principal auth.Principal) (dto.OrganizationResponse, error) {
+	ret := _m.Called(ctx, payload, principal)
+
+	var r0 dto.OrganizationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.OrganizationPayload, auth.Principal) dto.OrganizationResponse); ok {
+		r0 = rf(ctx, payload, principal)
+	} else {
+		r0 = ret.Get(0).(dto.OrganizationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.OrganizationPayload, auth.Principal) error); ok {
+		r1 = rf(ctx, payload, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrganization provides a mock function with given fields: ctx, id, principal
+func (_m *OrganizationUsecase) GetOrganization(ctx context.Context, id int, principal auth.Principal) (dto.OrganizationDetailResponse, error) {
+	ret := _m.Called(ctx, id, principal)
+
+	var r0 dto.OrganizationDetailResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) dto.OrganizationDetailResponse); ok {
+		r0 = rf(ctx, id, principal)
+	} else {
+		r0 = ret.Get(0).(dto.OrganizationDetailResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, auth.Principal) error); ok {
+		r1 = rf(ctx, id, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrganizations provides a mock function with given fields: ctx, principal
+func (_m *OrganizationUsecase) GetOrganizations(ctx context.Context, principal auth.Principal) ([]dto.OrganizationResponse, error) {
+	ret := _m.Called(ctx, principal)
+
+	var r0 []dto.OrganizationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Principal) []dto.OrganizationResponse); ok {
+		r0 = rf(ctx, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.OrganizationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Principal) error); ok {
+		r1 = rf(ctx, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMember provides a mock function with given fields: ctx, id, userId, principal
+func (_m *OrganizationUsecase) RemoveMember(ctx context.Context, id int, userId int, principal auth.Principal) error {
+	ret := _m.Called(ctx, id, userId, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, auth.Principal) error); ok {
+		r0 = rf(ctx, id, userId, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewOrganizationUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOrganizationUsecase creates a new instance of OrganizationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOrganizationUsecase(t mockConstructorTestingTNewOrganizationUsecase) *OrganizationUsecase {
+	mock := &OrganizationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,50 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevocationChecker is an autogenerated mock type for the RevocationChecker type
+type RevocationChecker struct {
+	mock.Mock
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti, userId
+func (_m *RevocationChecker) IsTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error) {
+	ret := _m.Called(ctx, jti, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) bool); ok {
+		r0 = rf(ctx, jti, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, jti, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRevocationChecker interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRevocationChecker creates a new instance of RevocationChecker. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRevocationChecker(t mockConstructorTestingTNewRevocationChecker) *RevocationChecker {
+	mock := &RevocationChecker{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,186 @@
+// Code generated by mockery v2.14.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	model "github.com/adityatresnobudi/job-portal/model"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TokenRepository is an autogenerated mock type for the TokenRepository type
+type TokenRepository struct {
+	mock.Mock
+}
+
+// CreateRefreshToken provides a mock function with given fields: ctx, token
+func (_m *TokenRepository) CreateRefreshToken(ctx context.Context, token model.RefreshTokens) (model.RefreshTokens, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 model.RefreshTokens
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokens) model.RefreshTokens); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(model.RefreshTokens)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshTokens) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUserToken provides a mock function with given fields: ctx, token
+func (_m *TokenRepository) CreateUserToken(ctx context.Context, token model.UserTokens) (model.UserTokens, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 model.UserTokens
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserTokens) model.UserTokens); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(model.UserTokens)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserTokens) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindRefreshTokenByHash provides a mock function with given fields: ctx, hash
+func (_m *TokenRepository) FindRefreshTokenByHash(ctx context.Context, hash string) (model.RefreshTokens, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 model.RefreshTokens
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.RefreshTokens); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(model.RefreshTokens)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsAccessTokenRevoked provides a mock function with given fields: ctx, jti, userId
+func (_m *TokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error) {
+	ret := _m.Called(ctx, jti, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) bool); ok {
+		r0 = rf(ctx, jti, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, jti, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeExpired provides a mock function with given fields: ctx, before
+func (_m *TokenRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAccessToken provides a mock function with given fields: ctx, jti, expiresAt
+func (_m *TokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, jti, expiresAt)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, jti, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeRefreshTokenFamily provides a mock function with given fields: ctx, familyId
+func (_m *TokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	ret := _m.Called(ctx, familyId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, familyId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateRefreshToken provides a mock function with given fields: ctx, current, next
+func (_m *TokenRepository) RotateRefreshToken(ctx context.Context, current model.RefreshTokens, next model.RefreshTokens) (model.RefreshTokens, error) {
+	ret := _m.Called(ctx, current, next)
+
+	var r0 model.RefreshTokens
+	if rf, ok := ret.Get(0).(func(context.Context, model.RefreshTokens, model.RefreshTokens) model.RefreshTokens); ok {
+		r0 = rf(ctx, current, next)
+	} else {
+		r0 = ret.Get(0).(model.RefreshTokens)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.RefreshTokens, model.RefreshTokens) error); ok {
+		r1 = rf(ctx, current, next)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenRepository creates a new instance of TokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenRepository(t mockConstructorTestingTNewTokenRepository) *TokenRepository {
+	mock := &TokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/adityatresnobudi/job-portal/model"
+	dto "github.com/adityatresnobudi/job-portal/dto"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/adityatresnobudi/job-portal/model"
+
+	time "time"
 )
 
 // UserJobRepository is an autogenerated mock type for the UserJobRepository type
@@ -14,20 +18,20 @@ type UserJobRepository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, newApply
-func (_m *UserJobRepository) Create(ctx context.Context, newApply model.UserJobs) (model.UserJobs, error) {
-	ret := _m.Called(ctx, newApply)
+// Apply provides a mock function with given fields: ctx, userId, jobId
+func (_m *UserJobRepository) Apply(ctx context.Context, userId uint, jobId uint) (model.UserJobs, error) {
+	ret := _m.Called(ctx, userId, jobId)
 
 	var r0 model.UserJobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.UserJobs) model.UserJobs); ok {
-		r0 = rf(ctx, newApply)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) model.UserJobs); ok {
+		r0 = rf(ctx, userId, jobId)
 	} else {
 		r0 = ret.Get(0).(model.UserJobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.UserJobs) error); ok {
-		r1 = rf(ctx, newApply)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, userId, jobId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,20 +39,20 @@ func (_m *UserJobRepository) Create(ctx context.Context, newApply model.UserJobs
 	return r0, r1
 }
 
-// FindById provides a mock function with given fields: ctx, jobId
-func (_m *UserJobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, error) {
-	ret := _m.Called(ctx, jobId)
+// FindByIdJobId provides a mock function with given fields: ctx, id, jobId
+func (_m *UserJobRepository) FindByIdJobId(ctx context.Context, id int, jobId int) (model.UserJobs, error) {
+	ret := _m.Called(ctx, id, jobId)
 
-	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, int) model.Jobs); ok {
-		r0 = rf(ctx, jobId)
+	var r0 model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) model.UserJobs); ok {
+		r0 = rf(ctx, id, jobId)
 	} else {
-		r0 = ret.Get(0).(model.Jobs)
+		r0 = ret.Get(0).(model.UserJobs)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = rf(ctx, jobId)
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, id, jobId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -56,6 +60,57 @@ func (_m *UserJobRepository) FindById(ctx context.Context, jobId int) (model.Job
 	return r0, r1
 }
 
+// FindByIdUserId provides a mock function with given fields: ctx, id, userId
+func (_m *UserJobRepository) FindByIdUserId(ctx context.Context, id int, userId uint) (model.UserJobs, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	var r0 model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, int, uint) model.UserJobs); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		r0 = ret.Get(0).(model.UserJobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, uint) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByJobId provides a mock function with given fields: ctx, jobId, query
+func (_m *UserJobRepository) FindByJobId(ctx context.Context, jobId int, query dto.ApplicantsQuery) ([]model.UserJobs, int64, error) {
+	ret := _m.Called(ctx, jobId, query)
+
+	var r0 []model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.ApplicantsQuery) []model.UserJobs); ok {
+		r0 = rf(ctx, jobId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserJobs)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.ApplicantsQuery) int64); ok {
+		r1 = rf(ctx, jobId, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, dto.ApplicantsQuery) error); ok {
+		r2 = rf(ctx, jobId, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindByJobIdUserId provides a mock function with given fields: ctx, jobId, userId
 func (_m *UserJobRepository) FindByJobIdUserId(ctx context.Context, jobId int, userId int) ([]model.UserJobs, error) {
 	ret := _m.Called(ctx, jobId, userId)
@@ -79,20 +134,166 @@ func (_m *UserJobRepository) FindByJobIdUserId(ctx context.Context, jobId int, u
 	return r0, r1
 }
 
-// UpdateMinusOneQuota provides a mock function with given fields: ctx, job
-func (_m *UserJobRepository) UpdateMinusOneQuota(ctx context.Context, job model.Jobs) (model.Jobs, error) {
-	ret := _m.Called(ctx, job)
+// FindByUserId provides a mock function with given fields: ctx, userId, query
+func (_m *UserJobRepository) FindByUserId(ctx context.Context, userId uint, query dto.ApplicationsQuery) ([]model.UserJobs, int64, error) {
+	ret := _m.Called(ctx, userId, query)
+
+	var r0 []model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.ApplicationsQuery) []model.UserJobs); ok {
+		r0 = rf(ctx, userId, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserJobs)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.ApplicationsQuery) int64); ok {
+		r1 = rf(ctx, userId, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, uint, dto.ApplicationsQuery) error); ok {
+		r2 = rf(ctx, userId, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindDeleted provides a mock function with given fields: ctx, query
+func (_m *UserJobRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.UserJobs, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []model.UserJobs); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserJobs)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
 
-	var r0 model.Jobs
-	if rf, ok := ret.Get(0).(func(context.Context, model.Jobs) model.Jobs); ok {
-		r0 = rf(ctx, job)
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
 	} else {
-		r0 = ret.Get(0).(model.Jobs)
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FindStatusHistory provides a mock function with given fields: ctx, applicationId
+func (_m *UserJobRepository) FindStatusHistory(ctx context.Context, applicationId int) ([]model.ApplicationStatusHistories, error) {
+	ret := _m.Called(ctx, applicationId)
+
+	var r0 []model.ApplicationStatusHistories
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ApplicationStatusHistories); ok {
+		r0 = rf(ctx, applicationId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ApplicationStatusHistories)
+		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, model.Jobs) error); ok {
-		r1 = rf(ctx, job)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, applicationId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *UserJobRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id, restoredBy
+func (_m *UserJobRepository) Restore(ctx context.Context, id int, restoredBy uint) (model.UserJobs, error) {
+	ret := _m.Called(ctx, id, restoredBy)
+
+	var r0 model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, int, uint) model.UserJobs); ok {
+		r0 = rf(ctx, id, restoredBy)
+	} else {
+		r0 = ret.Get(0).(model.UserJobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, uint) error); ok {
+		r1 = rf(ctx, id, restoredBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatus provides a mock function with given fields: ctx, application, status, changedBy, note
+func (_m *UserJobRepository) UpdateStatus(ctx context.Context, application model.UserJobs, status string, changedBy uint, note string) (model.UserJobs, error) {
+	ret := _m.Called(ctx, application, status, changedBy, note)
+
+	var r0 model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserJobs, string, uint, string) model.UserJobs); ok {
+		r0 = rf(ctx, application, status, changedBy, note)
+	} else {
+		r0 = ret.Get(0).(model.UserJobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserJobs, string, uint, string) error); ok {
+		r1 = rf(ctx, application, status, changedBy, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Withdraw provides a mock function with given fields: ctx, application
+func (_m *UserJobRepository) Withdraw(ctx context.Context, application model.UserJobs) (model.UserJobs, error) {
+	ret := _m.Called(ctx, application)
+
+	var r0 model.UserJobs
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserJobs) model.UserJobs); ok {
+		r0 = rf(ctx, application)
+	} else {
+		r0 = ret.Get(0).(model.UserJobs)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserJobs) error); ok {
+		r1 = rf(ctx, application)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,7 +5,10 @@ package mocks
 import (
 	context "context"
 
+	auth "github.com/adityatresnobudi/job-portal/auth"
+
 	dto "github.com/adityatresnobudi/job-portal/dto"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,20 +17,217 @@ type UserJobUsecase struct {
 	mock.Mock
 }
 
-// ApplyJob provides a mock function with given fields: ctx, job, userId
-func (_m *UserJobUsecase) ApplyJob(ctx context.Context, job dto.UserJobsPayload, userId int) (dto.UserJobsDTO, error) {
-	ret := _m.Called(ctx, job, userId)
+// ApplyJob provides a mock function with given fields: ctx, job, principal
+func (_m *UserJobUsecase) ApplyJob(ctx context.Context, job dto.UserJobsPayload, principal auth.Principal) (dto.UserJobsDTO, error) {
+	ret := _m.Called(ctx, job, principal)
 
 	var r0 dto.UserJobsDTO
-	if rf, ok := ret.Get(0).(func(context.Context, dto.UserJobsPayload, int) dto.UserJobsDTO); ok {
-		r0 = rf(ctx, job, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, dto.UserJobsPayload, auth.Principal) dto.UserJobsDTO); ok {
+		r0 = rf(ctx, job, principal)
 	} else {
 		r0 = ret.Get(0).(dto.UserJobsDTO)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, dto.UserJobsPayload, int) error); ok {
-		r1 = rf(ctx, job, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, dto.UserJobsPayload, auth.Principal) error); ok {
+		r1 = rf(ctx, job, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ChangeApplicationStatus provides a mock function with given fields: ctx, jobId, id, payload, principal
+func (_m *UserJobUsecase) ChangeApplicationStatus(ctx context.Context, jobId int, id int, payload dto.ApplicationStatusPayload, principal auth.Principal) (dto.ApplicationResponse, error) {
+	ret := _m.Called(ctx, jobId, id, payload, principal)
+
+	var r0 dto.ApplicationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, dto.ApplicationStatusPayload, auth.Principal) dto.ApplicationResponse); ok {
+		r0 = rf(ctx, jobId, id, payload, principal)
+	} else {
+		r0 = ret.Get(0).(dto.ApplicationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, dto.ApplicationStatusPayload, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, id, payload, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApplication provides a mock function with given fields: ctx, id, principal
+func (_m *UserJobUsecase) GetApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error) {
+	ret := _m.Called(ctx, id, principal)
+
+	var r0 dto.ApplicationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) dto.ApplicationResponse); ok {
+		r0 = rf(ctx, id, principal)
+	} else {
+		r0 = ret.Get(0).(dto.ApplicationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, auth.Principal) error); ok {
+		r1 = rf(ctx, id, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApplicationHistory provides a mock function with given fields: ctx, jobId, id, principal
+func (_m *UserJobUsecase) GetApplicationHistory(ctx context.Context, jobId int, id int, principal auth.Principal) ([]dto.ApplicationStatusHistoryDTO, error) {
+	ret := _m.Called(ctx, jobId, id, principal)
+
+	var r0 []dto.ApplicationStatusHistoryDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, int, auth.Principal) []dto.ApplicationStatusHistoryDTO); ok {
+		r0 = rf(ctx, jobId, id, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ApplicationStatusHistoryDTO)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int, auth.Principal) error); ok {
+		r1 = rf(ctx, jobId, id, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetApplications provides a mock function with given fields: ctx, principal, query
+func (_m *UserJobUsecase) GetApplications(ctx context.Context, principal auth.Principal, query dto.ApplicationsQuery) ([]dto.ApplicationResponse, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, principal, query)
+
+	var r0 []dto.ApplicationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, auth.Principal, dto.ApplicationsQuery) []dto.ApplicationResponse); ok {
+		r0 = rf(ctx, principal, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ApplicationResponse)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, auth.Principal, dto.ApplicationsQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, principal, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, auth.Principal, dto.ApplicationsQuery) error); ok {
+		r2 = rf(ctx, principal, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDeletedApplications provides a mock function with given fields: ctx, query
+func (_m *UserJobUsecase) GetDeletedApplications(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedApplicationDTO, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []dto.DeletedApplicationDTO
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []dto.DeletedApplicationDTO); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DeletedApplicationDTO)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetJobApplicants provides a mock function with given fields: ctx, jobId, query, principal
+func (_m *UserJobUsecase) GetJobApplicants(ctx context.Context, jobId int, query dto.ApplicantsQuery, principal auth.Principal) ([]dto.ApplicantResponse, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, jobId, query, principal)
+
+	var r0 []dto.ApplicantResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, dto.ApplicantsQuery, auth.Principal) []dto.ApplicantResponse); ok {
+		r0 = rf(ctx, jobId, query, principal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ApplicantResponse)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, int, dto.ApplicantsQuery, auth.Principal) dto.PaginationResponse); ok {
+		r1 = rf(ctx, jobId, query, principal)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, int, dto.ApplicantsQuery, auth.Principal) error); ok {
+		r2 = rf(ctx, jobId, query, principal)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RestoreApplication provides a mock function with given fields: ctx, id, principal
+func (_m *UserJobUsecase) RestoreApplication(ctx context.Context, id int, principal auth.Principal) (dto.DeletedApplicationDTO, error) {
+	ret := _m.Called(ctx, id, principal)
+
+	var r0 dto.DeletedApplicationDTO
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) dto.DeletedApplicationDTO); ok {
+		r0 = rf(ctx, id, principal)
+	} else {
+		r0 = ret.Get(0).(dto.DeletedApplicationDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, auth.Principal) error); ok {
+		r1 = rf(ctx, id, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WithdrawApplication provides a mock function with given fields: ctx, id, principal
+func (_m *UserJobUsecase) WithdrawApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error) {
+	ret := _m.Called(ctx, id, principal)
+
+	var r0 dto.ApplicationResponse
+	if rf, ok := ret.Get(0).(func(context.Context, int, auth.Principal) dto.ApplicationResponse); ok {
+		r0 = rf(ctx, id, principal)
+	} else {
+		r0 = ret.Get(0).(dto.ApplicationResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, auth.Principal) error); ok {
+		r1 = rf(ctx, id, principal)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
-	model "github.com/adityatresnobudi/job-portal/model"
+	dto "github.com/adityatresnobudi/job-portal/dto"
 	mock "github.com/stretchr/testify/mock"
+
+	model "github.com/adityatresnobudi/job-portal/model"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,6 +18,27 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// ChangeEmail provides a mock function with given fields: ctx, tokenHash
+func (_m *UserRepository) ChangeEmail(ctx context.Context, tokenHash string) (model.Users, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Users); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, user
 func (_m *UserRepository) Create(ctx context.Context, user model.Users) (model.Users, error) {
 	ret := _m.Called(ctx, user)
@@ -35,6 +60,20 @@ func (_m *UserRepository) Create(ctx context.Context, user model.Users) (model.U
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Delete(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FindByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (model.Users, error) {
 	ret := _m.Called(ctx, email)
@@ -56,6 +95,176 @@ func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (model.
 	return r0, r1
 }
 
+// FindById provides a mock function with given fields: ctx, id
+func (_m *UserRepository) FindById(ctx context.Context, id uint) (model.Users, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, uint) model.Users); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDeleted provides a mock function with given fields: ctx, query
+func (_m *UserRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Users, int64, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []model.Users); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Users)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) int64); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MarkEmailVerified provides a mock function with given fields: ctx, id, email
+func (_m *UserRepository) MarkEmailVerified(ctx context.Context, id uint, email string) (model.Users, error) {
+	ret := _m.Called(ctx, id, email)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) model.Users); ok {
+		r0 = rf(ctx, id, email)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, id, email)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeleted provides a mock function with given fields: ctx, before
+func (_m *UserRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResetPassword provides a mock function with given fields: ctx, tokenHash, passwordHash
+func (_m *UserRepository) ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (model.Users, error) {
+	ret := _m.Called(ctx, tokenHash, passwordHash)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.Users); ok {
+		r0 = rf(ctx, tokenHash, passwordHash)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tokenHash, passwordHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Restore(ctx context.Context, id uint) (model.Users, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, uint) model.Users); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, user, fields
+func (_m *UserRepository) Update(ctx context.Context, user model.Users, fields []string) (model.Users, error) {
+	ret := _m.Called(ctx, user, fields)
+
+	var r0 model.Users
+	if rf, ok := ret.Get(0).(func(context.Context, model.Users, []string) model.Users); ok {
+		r0 = rf(ctx, user, fields)
+	} else {
+		r0 = ret.Get(0).(model.Users)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.Users, []string) error); ok {
+		r1 = rf(ctx, user, fields)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, passwordHash
+func (_m *UserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	ret := _m.Called(ctx, id, passwordHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, id, passwordHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUserRepository interface {
 	mock.TestingT
 	Cleanup(func())
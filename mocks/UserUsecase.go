@@ -5,8 +5,13 @@ package mocks
 import (
 	context "context"
 
+	auth "github.com/adityatresnobudi/job-portal/auth"
+
 	dto "github.com/adityatresnobudi/job-portal/dto"
+
 	mock "github.com/stretchr/testify/mock"
+
+	url "net/url"
 )
 
 // UserUsecase is an autogenerated mock type for the UserUsecase type
@@ -14,6 +19,41 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, req, principal
+func (_m *UserUsecase) ChangePassword(ctx context.Context, req dto.ChangePasswordRequest, principal auth.Principal) error {
+	ret := _m.Called(ctx, req, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ChangePasswordRequest, auth.Principal) error); ok {
+		r0 = rf(ctx, req, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConfirmEmail provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) ConfirmEmail(ctx context.Context, req dto.ConfirmEmailRequest) (dto.UserResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.UserResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ConfirmEmailRequest) dto.UserResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.UserResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.ConfirmEmailRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUsers provides a mock function with given fields: ctx, user
 func (_m *UserUsecase) CreateUsers(ctx context.Context, user dto.UserPayload) (dto.UserResponse, error) {
 	ret := _m.Called(ctx, user)
@@ -35,6 +75,127 @@ func (_m *UserUsecase) CreateUsers(ctx context.Context, user dto.UserPayload) (d
 	return r0, r1
 }
 
+// DeleteUser provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) DeleteUser(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ForgotPassword provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ForgotPasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeletedUsers provides a mock function with given fields: ctx, query
+func (_m *UserUsecase) GetDeletedUsers(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedUserDTO, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query)
+
+	var r0 []dto.DeletedUserDTO
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationQuery) []dto.DeletedUserDTO); ok {
+		r0 = rf(ctx, query)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.DeletedUserDTO)
+		}
+	}
+
+	var r1 dto.PaginationResponse
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationQuery) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationQuery) error); ok {
+		r2 = rf(ctx, query)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetProfile provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) GetProfile(ctx context.Context, id uint) (dto.UserResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 dto.UserResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.UserResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.UserResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPublicProfile provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) GetPublicProfile(ctx context.Context, id uint) (dto.PublicProfileResponse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 dto.PublicProfileResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.PublicProfileResponse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.PublicProfileResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsTokenRevoked provides a mock function with given fields: ctx, jti, userId
+func (_m *UserUsecase) IsTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error) {
+	ret := _m.Called(ctx, jti, userId)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) bool); ok {
+		r0 = rf(ctx, jti, userId)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, uint) error); ok {
+		r1 = rf(ctx, jti, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoginUser provides a mock function with given fields: ctx, req
 func (_m *UserUsecase) LoginUser(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -56,6 +217,132 @@ func (_m *UserUsecase) LoginUser(ctx context.Context, req dto.LoginRequest) (dto
 	return r0, r1
 }
 
+// Logout provides a mock function with given fields: ctx, req, principal
+func (_m *UserUsecase) Logout(ctx context.Context, req dto.LogoutRequest, principal auth.Principal) error {
+	ret := _m.Called(ctx, req, principal)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.LogoutRequest, auth.Principal) error); ok {
+		r0 = rf(ctx, req, principal)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RefreshToken provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) RefreshToken(ctx context.Context, req dto.RefreshRequest) (dto.LoginResponse, error) {
+	ret := _m.Called(ctx, req)
+
+	var r0 dto.LoginResponse
+	if rf, ok := ret.Get(0).(func(context.Context, dto.RefreshRequest) dto.LoginResponse); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Get(0).(dto.LoginResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, dto.RefreshRequest) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResendVerification provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) ResendVerification(ctx context.Context, id uint) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetPassword provides a mock function with given fields: ctx, req
+func (_m *UserUsecase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	ret := _m.Called(ctx, req)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.ResetPasswordRequest) error); ok {
+		r0 = rf(ctx, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RestoreUser provides a mock function with given fields: ctx, id
+func (_m *UserUsecase) RestoreUser(ctx context.Context, id uint) (dto.DeletedUserDTO, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 dto.DeletedUserDTO
+	if rf, ok := ret.Get(0).(func(context.Context, uint) dto.DeletedUserDTO); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(dto.DeletedUserDTO)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, id, req
+func (_m *UserUsecase) UpdateProfile(ctx context.Context, id uint, req dto.UpdateProfileRequest) (dto.UpdateProfileResponse, error) {
+	ret := _m.Called(ctx, id, req)
+
+	var r0 dto.UpdateProfileResponse
+	if rf, ok := ret.Get(0).(func(context.Context, uint, dto.UpdateProfileRequest) dto.UpdateProfileResponse); ok {
+		r0 = rf(ctx, id, req)
+	} else {
+		r0 = ret.Get(0).(dto.UpdateProfileResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, uint, dto.UpdateProfileRequest) error); ok {
+		r1 = rf(ctx, id, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyEmail provides a mock function with given fields: ctx, link
+func (_m *UserUsecase) VerifyEmail(ctx context.Context, link url.Values) (dto.UserResponse, error) {
+	ret := _m.Called(ctx, link)
+
+	var r0 dto.UserResponse
+	if rf, ok := ret.Get(0).(func(context.Context, url.Values) dto.UserResponse); ok {
+		r0 = rf(ctx, link)
+	} else {
+		r0 = ret.Get(0).(dto.UserResponse)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, url.Values) error); ok {
+		r1 = rf(ctx, link)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUserUsecase interface {
 	mock.TestingT
 	Cleanup(func())
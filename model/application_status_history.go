@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type ApplicationStatusHistories struct {
+	ID            uint      `gorm:"primary_key;column:id"`
+	ApplicationId uint      `gorm:"column:application_id"`
+	FromStatus    string    `gorm:"column:from_status"`
+	ToStatus      string    `gorm:"column:to_status"`
+	ChangedBy     uint      `gorm:"column:changed_by"`
+	Note          string    `gorm:"column:note"`
+	CreatedAt     time.Time `gorm:"column:created_at"`
+}
@@ -1,17 +1,40 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	JobStatusDraft     = "draft"
+	JobStatusPublished = "published"
+	JobStatusPaused    = "paused"
+	JobStatusClosed    = "closed"
+	JobStatusArchived  = "archived"
+)
+
+const (
+	JobCloseReasonExpired = "expired"
+	JobCloseReasonFilled  = "quota_filled"
+)
+
+// JobReopenReasonQuotaReturned reopens a job closed as quota_filled once a
+// withdrawn application gives a slot back.
+const JobReopenReasonQuotaReturned = "quota_returned"
 
 type Jobs struct {
-	ID          uint      `gorm:"primary_key;column:id"`
-	JobPosterId uint      `gorm:"column:job_poster_id"`
-	JobPoster   Users     `gorm:"foreignKey:JobPosterId"`
-	JobName     string    `gorm:"column:job_name"`
-	JobDesc     string    `gorm:"column:job_desc"`
-	Quota       int       `gorm:"column:quota"`
-	IsOpen      bool      `gorm:"column:is_open"`
-	ExpiryDate  time.Time `gorm:"column:expiry_date"`
-	CreatedAt   time.Time `gorm:"column:created_at" json:"-"`
-	UpdatedAt   time.Time `gorm:"column:updated_at" json:"-"`
-	DeletedAt   time.Time `gorm:"column:deleted_at" json:"-"`
+	ID             uint           `gorm:"primary_key;column:id"`
+	OrganizationId uint           `gorm:"column:organization_id"`
+	JobPosterId    uint           `gorm:"column:job_poster_id"`
+	JobPoster      Users          `gorm:"foreignKey:JobPosterId"`
+	JobName        string         `gorm:"column:job_name"`
+	JobDesc        string         `gorm:"column:job_desc"`
+	Quota          int            `gorm:"column:quota"`
+	Status         string         `gorm:"column:status"`
+	ExpiryDate     time.Time      `gorm:"column:expiry_date"`
+	Version        int            `gorm:"column:version;default:1"`
+	CreatedAt      time.Time      `gorm:"column:created_at" json:"-"`
+	UpdatedAt      time.Time      `gorm:"column:updated_at" json:"-"`
+	DeletedAt      gorm.DeletedAt `gorm:"column:deleted_at" json:"-"`
 }
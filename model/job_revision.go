@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+// Reasons of a job revision besides the job actions and close reasons that
+// change its status.
+const (
+	JobRevisionReasonBaseline = "baseline"
+	JobRevisionReasonCreated  = "created"
+	JobRevisionReasonEdited   = "edited"
+	// Quota taken or given back by applications.
+	JobRevisionReasonApplied             = "applied"
+	JobRevisionReasonWithdrawn           = "withdrawn"
+	JobRevisionReasonApplicationRestored = "application_restored"
+)
+
+// JobRevisions is a job's posting as it was at Version.
+type JobRevisions struct {
+	ID         uint      `gorm:"primary_key;column:id"`
+	JobId      uint      `gorm:"column:job_id"`
+	Version    int       `gorm:"column:version"`
+	ChangedBy  uint      `gorm:"column:changed_by"`
+	Reason     string    `gorm:"column:reason"`
+	JobName    string    `gorm:"column:job_name"`
+	JobDesc    string    `gorm:"column:job_desc"`
+	Quota      int       `gorm:"column:quota"`
+	Status     string    `gorm:"column:status"`
+	ExpiryDate time.Time `gorm:"column:expiry_date"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
+
+// NewJobRevision records job as it is now.
+func NewJobRevision(job Jobs, changedBy uint, reason string) JobRevisions {
+	return JobRevisions{
+		JobId:      job.ID,
+		Version:    job.Version,
+		ChangedBy:  changedBy,
+		Reason:     reason,
+		JobName:    job.JobName,
+		JobDesc:    job.JobDesc,
+		Quota:      job.Quota,
+		Status:     job.Status,
+		ExpiryDate: job.ExpiryDate,
+	}
+}
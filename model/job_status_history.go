@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+type JobStatusHistories struct {
+	ID         uint      `gorm:"primary_key;column:id"`
+	JobId      uint      `gorm:"column:job_id"`
+	FromStatus string    `gorm:"column:from_status"`
+	ToStatus   string    `gorm:"column:to_status"`
+	ChangedBy  uint      `gorm:"column:changed_by"`
+	Reason     string    `gorm:"column:reason"`
+	CreatedAt  time.Time `gorm:"column:created_at"`
+}
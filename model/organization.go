@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+const (
+	OrganizationRoleOwner     = "owner"
+	OrganizationRoleAdmin     = "admin"
+	OrganizationRoleRecruiter = "recruiter"
+)
+
+// Organizations own job postings; every member may manage its jobs and their
+// applicants. CreatedBy is nil once the creator has been purged.
+type Organizations struct {
+	ID        uint      `gorm:"primary_key;column:id"`
+	Name      string    `gorm:"column:name"`
+	CreatedBy *uint     `gorm:"column:created_by"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at"`
+}
+
+type OrganizationMembers struct {
+	ID             uint          `gorm:"primary_key;column:id"`
+	OrganizationId uint          `gorm:"column:organization_id"`
+	Organization   Organizations `gorm:"foreignKey:OrganizationId"`
+	UserId         uint          `gorm:"column:user_id"`
+	User           Users         `gorm:"foreignKey:UserId"`
+	Role           string        `gorm:"column:role"`
+	CreatedAt      time.Time     `gorm:"column:created_at"`
+	UpdatedAt      time.Time     `gorm:"column:updated_at"`
+}
+
+// CanManageMembers reports whether the member may add, change and remove
+// members. Only owners may touch other owners.
+func (m OrganizationMembers) CanManageMembers() bool {
+	return m.Role == OrganizationRoleOwner || m.Role == OrganizationRoleAdmin
+}
@@ -0,0 +1,19 @@
+package model
+
+import "time"
+
+type RefreshTokens struct {
+	ID        uint       `gorm:"primary_key;column:id"`
+	UserId    uint       `gorm:"column:user_id"`
+	TokenHash string     `gorm:"column:token_hash;uniqueIndex"`
+	FamilyId  string     `gorm:"column:family_id;index"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
+
+type RevokedTokens struct {
+	Jti       string    `gorm:"primary_key;column:jti"`
+	ExpiresAt time.Time `gorm:"column:expires_at"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
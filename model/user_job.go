@@ -1,14 +1,38 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	ApplicationStatusApplied   = "applied"
+	ApplicationStatusScreening = "screening"
+	ApplicationStatusInterview = "interview"
+	ApplicationStatusOffered   = "offered"
+	ApplicationStatusHired     = "hired"
+	ApplicationStatusRejected  = "rejected"
+	ApplicationStatusWithdrawn = "withdrawn"
+)
+
+// OpenApplicationStatuses are those of applications no hiring decision has
+// been made on yet; they may still be withdrawn.
+var OpenApplicationStatuses = []string{
+	ApplicationStatusApplied,
+	ApplicationStatusScreening,
+	ApplicationStatusInterview,
+	ApplicationStatusOffered,
+}
 
 type UserJobs struct {
-	ID        uint      `gorm:"primary_key;column:id"`
-	JobId     uint      `gorm:"column:job_id"`
-	Jobs      Jobs      `gorm:"foreignKey:JobId" json:"jobs"`
-	UserId    uint      `gorm:"column:user_id"`
-	Users     Users     `gorm:"foreignKey:UserId" json:"users"`
-	CreatedAt time.Time `gorm:"column:created_at" json:"-"`
-	UpdatedAt time.Time `gorm:"column:updated_at" json:"-"`
-	DeletedAt time.Time `gorm:"column:deleted_at" json:"-"`
+	ID        uint           `gorm:"primary_key;column:id"`
+	JobId     uint           `gorm:"column:job_id;uniqueIndex:idx_user_jobs_user_id_job_id,where:deleted_at IS NULL"`
+	Jobs      Jobs           `gorm:"foreignKey:JobId" json:"jobs"`
+	UserId    uint           `gorm:"column:user_id;uniqueIndex:idx_user_jobs_user_id_job_id,where:deleted_at IS NULL"`
+	Users     Users          `gorm:"foreignKey:UserId" json:"users"`
+	Status    string         `gorm:"column:status"`
+	CreatedAt time.Time      `gorm:"column:created_at" json:"-"`
+	UpdatedAt time.Time      `gorm:"column:updated_at" json:"-"`
+	DeletedAt gorm.DeletedAt `gorm:"column:deleted_at" json:"-"`
 }
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+const (
+	UserTokenPurposeEmailChange   = "email_change"
+	UserTokenPurposePasswordReset = "password_reset"
+)
+
+// UserTokens are single-use tokens sent to a user to confirm an action. Only
+// the hash of the token is stored.
+type UserTokens struct {
+	ID        uint       `gorm:"primary_key;column:id"`
+	UserId    uint       `gorm:"column:user_id"`
+	Purpose   string     `gorm:"column:purpose"`
+	TokenHash string     `gorm:"column:token_hash;uniqueIndex"`
+	Email     string     `gorm:"column:email"`
+	ExpiresAt time.Time  `gorm:"column:expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at"`
+	CreatedAt time.Time  `gorm:"column:created_at"`
+}
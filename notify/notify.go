@@ -0,0 +1,164 @@
+// Package notify delivers messages, such as confirmation links, to users.
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/logger"
+	"github.com/sirupsen/logrus"
+)
+
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// NewNotifier returns the Notifier selected by cfg.Driver.
+func NewNotifier(cfg config.Notify, log logger.Logger) (Notifier, error) {
+	switch cfg.Driver {
+	case config.NotifyDriverLog:
+		return NewLogNotifier(log), nil
+	case config.NotifyDriverFile:
+		return NewFileNotifier(cfg.File), nil
+	case config.NotifyDriverSMTP:
+		return NewSMTPNotifier(cfg), nil
+	default:
+		return nil, fmt.Errorf("unknown notify driver %q", cfg.Driver)
+	}
+}
+
+type logNotifier struct {
+	log logger.Logger
+}
+
+// NewLogNotifier returns a Notifier that writes every message to the log.
+// It stands in until the service can send email.
+func NewLogNotifier(log logger.Logger) Notifier {
+	return &logNotifier{
+		log: log,
+	}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, msg Message) error {
+	n.log.Info(logrus.Fields{
+		"notification": msg.Subject,
+		"to":           msg.To,
+		"body":         msg.Body,
+	})
+	return nil
+}
+
+type fileNotifier struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileNotifier returns a Notifier that appends every message to path as a
+// JSON line, so a developer or a test can pick up links without a mail server.
+func NewFileNotifier(path string) Notifier {
+	return &fileNotifier{
+		path: path,
+	}
+}
+
+type fileEntry struct {
+	Message
+	SentAt time.Time `json:"sent_at"`
+}
+
+func (n *fileNotifier) Notify(ctx context.Context, msg Message) error {
+	line, err := json.Marshal(fileEntry{Message: msg, SentAt: time.Now()})
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	f, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type smtpNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPNotifier returns a Notifier that sends every message as a plain text
+// email through the SMTP server at cfg.SMTPAddr.
+func NewSMTPNotifier(cfg config.Notify) Notifier {
+	n := &smtpNotifier{
+		addr: cfg.SMTPAddr,
+		from: cfg.From,
+	}
+	if cfg.SMTPUsername != "" {
+		host, _, _ := net.SplitHostPort(cfg.SMTPAddr)
+		n.auth = smtp.PlainAuth("", cfg.SMTPUsername, cfg.SMTPPassword.Value(), host)
+	}
+	return n
+}
+
+// Notify does not honour ctx; net/smtp has no way to cancel a send.
+func (n *smtpNotifier) Notify(ctx context.Context, msg Message) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(msg.Body)
+
+	return smtp.SendMail(n.addr, n.auth, n.from, []string{msg.To}, []byte(b.String()))
+}
+
+// MemoryNotifier keeps every message in memory. It is meant for tests that
+// need to read what was sent.
+type MemoryNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewMemoryNotifier() *MemoryNotifier {
+	return &MemoryNotifier{}
+}
+
+func (n *MemoryNotifier) Notify(ctx context.Context, msg Message) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first.
+func (n *MemoryNotifier) Messages() []Message {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	messages := make([]Message, len(n.messages))
+	copy(messages, n.messages)
+	return messages
+}
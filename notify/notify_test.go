@@ -0,0 +1,33 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileNotifier(t *testing.T) {
+	t.Run("should append every message as a JSON line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "notifications.log")
+		n := notify.NewFileNotifier(path)
+
+		require.NoError(t, n.Notify(context.Background(), notify.Message{To: "a@mail.com", Subject: "first", Body: "one"}))
+		require.NoError(t, n.Notify(context.Background(), notify.Message{To: "b@mail.com", Subject: "second", Body: "two"}))
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 2)
+
+		msg := notify.Message{}
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &msg))
+		assert.Equal(t, notify.Message{To: "b@mail.com", Subject: "second", Body: "two"}, msg)
+	})
+}
@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -14,32 +17,86 @@ type jobRepository struct {
 }
 
 type JobRepository interface {
-	FindAll(ctx context.Context, name string) ([]model.Jobs, error)
+	FindAll(ctx context.Context, query dto.JobsQuery) ([]model.Jobs, int64, error)
 	FindById(ctx context.Context, jobId int) (model.Jobs, error)
-	Create(ctx context.Context, newJob model.Jobs) (model.Jobs, error)
-	Delete(ctx context.Context, job model.Jobs) (model.Jobs, error)
-	UpdateQuota(ctx context.Context, job model.Jobs, quota int) (model.Jobs, error)
-	UpdateExpDate(ctx context.Context, job model.Jobs, expDate time.Time) (model.Jobs, error)
+	FindByIdWithPoster(ctx context.Context, jobId int) (model.Jobs, error)
+	Create(ctx context.Context, newJob model.Jobs, createdBy uint) (model.Jobs, error)
+	FindAnyById(ctx context.Context, jobId int) (model.Jobs, error)
+	UpdateStatus(ctx context.Context, job model.Jobs, status string, changedBy uint, reason string) (model.Jobs, error)
+	FindStatusHistory(ctx context.Context, jobId int) ([]model.JobStatusHistories, error)
+	CloseExpired(ctx context.Context) ([]model.Jobs, error)
+	CloseFilled(ctx context.Context) ([]model.Jobs, error)
+	Update(ctx context.Context, job model.Jobs, fields map[string]interface{}, changedBy uint, versions []int) (model.Jobs, error)
+	FindRevisions(ctx context.Context, jobId int, query dto.PaginationQuery) ([]model.JobRevisions, int64, error)
+	FindRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error)
+	FindPreviousRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error)
+	Delete(ctx context.Context, job model.Jobs) error
+	FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Jobs, int64, error)
+	Restore(ctx context.Context, jobId int) (model.Jobs, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
+// bumpVersion is set alongside every change of a job row, so the version a
+// client read tells whether its copy is still current.
+var bumpVersion = gorm.Expr("version + 1")
+
 func NewJobRepository(db *gorm.DB) JobRepository {
 	return &jobRepository{
 		db: db,
 	}
 }
 
-func (j *jobRepository) FindAll(ctx context.Context, name string) ([]model.Jobs, error) {
+func (j *jobRepository) FindAll(ctx context.Context, query dto.JobsQuery) ([]model.Jobs, int64, error) {
 	jobs := []model.Jobs{}
+	var total int64
 
-	err := j.db.WithContext(ctx).
+	tx := j.db.WithContext(ctx).
 		Model(&model.Jobs{}).
-		Where("job_name ILIKE ? AND expiry_date > NOW() AND is_open IS TRUE", "%"+name+"%").
+		Where("job_name ILIKE ? AND expiry_date > NOW() AND status = ?", "%"+query.Name+"%", model.JobStatusPublished)
+
+	if query.JobPosterId != 0 {
+		tx = tx.Where("job_poster_id = ?", query.JobPosterId)
+	}
+	if query.OrganizationId != 0 {
+		tx = tx.Where("organization_id = ?", query.OrganizationId)
+	}
+	if query.MinQuota > 0 {
+		tx = tx.Where("quota >= ?", query.MinQuota)
+	}
+	if !query.ExpiresAfter.IsZero() {
+		tx = tx.Where("expiry_date >= ?", query.ExpiresAfter)
+	}
+	if !query.ExpiresBefore.IsZero() {
+		tx = tx.Where("expiry_date < ?", query.ExpiresBefore)
+	}
+	if !query.CreatedAfter.IsZero() {
+		tx = tx.Where("created_at >= ?", query.CreatedAfter)
+	}
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Order(jobsOrder(query.Sort)).
+		Offset(query.Offset()).
+		Limit(query.Limit).
 		Find(&jobs).Error
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return jobs, nil
+	return jobs, total, nil
+}
+
+func jobsOrder(sort string) string {
+	switch sort {
+	case dto.SortJobsExpiring:
+		return "expiry_date ASC, id ASC"
+	case dto.SortJobsQuota:
+		return "quota DESC, id ASC"
+	default:
+		return "created_at DESC, id DESC"
+	}
 }
 
 func (j *jobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, error) {
@@ -47,17 +104,45 @@ func (j *jobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, er
 
 	err := j.db.WithContext(ctx).
 		Model(&model.Jobs{}).
-		Where("id = ? AND expiry_date > NOW() AND is_open IS TRUE", jobId).
+		Where("id = ? AND expiry_date > NOW() AND status = ?", jobId, model.JobStatusPublished).
+		First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Jobs{}, shared.ErrRecordNotFound
+		}
+		return model.Jobs{}, err
+	}
+
+	return job, nil
+}
+
+func (j *jobRepository) FindByIdWithPoster(ctx context.Context, jobId int) (model.Jobs, error) {
+	job := model.Jobs{}
+
+	err := j.db.WithContext(ctx).
+		Model(&model.Jobs{}).
+		Preload("JobPoster").
+		Where("id = ? AND expiry_date > NOW() AND status = ?", jobId, model.JobStatusPublished).
 		First(&job).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Jobs{}, shared.ErrRecordNotFound
+		}
 		return model.Jobs{}, err
 	}
 
 	return job, nil
 }
 
-func (j *jobRepository) Create(ctx context.Context, newJob model.Jobs) (model.Jobs, error) {
-	err := j.db.WithContext(ctx).Model(&model.Jobs{}).Create(&newJob).Error
+func (j *jobRepository) Create(ctx context.Context, newJob model.Jobs, createdBy uint) (model.Jobs, error) {
+	newJob.Version = 1
+	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Jobs{}).Create(&newJob).Error; err != nil {
+			return err
+		}
+		revision := model.NewJobRevision(newJob, createdBy, model.JobRevisionReasonCreated)
+		return tx.Create(&revision).Error
+	})
 	if err != nil {
 		return model.Jobs{}, err
 	}
@@ -65,42 +150,318 @@ func (j *jobRepository) Create(ctx context.Context, newJob model.Jobs) (model.Jo
 	return newJob, nil
 }
 
-func (j *jobRepository) Delete(ctx context.Context, job model.Jobs) (model.Jobs, error) {
+func (j *jobRepository) FindAnyById(ctx context.Context, jobId int) (model.Jobs, error) {
+	job := model.Jobs{}
+
+	err := j.db.WithContext(ctx).
+		Model(&model.Jobs{}).
+		Where("id = ?", jobId).
+		First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Jobs{}, shared.ErrRecordNotFound
+		}
+		return model.Jobs{}, err
+	}
+
+	return job, nil
+}
+
+func (j *jobRepository) UpdateStatus(ctx context.Context, job model.Jobs, status string, changedBy uint, reason string) (model.Jobs, error) {
 	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		job := model.Jobs{}
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&job, job.ID).Error; err != nil {
+		locked := model.Jobs{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, job.ID).Error; err != nil {
+			return err
+		}
+		if locked.Status != job.Status {
+			return shared.ErrJobStatusConflict
+		}
+		if err := tx.Model(&locked).Updates(map[string]interface{}{"status": status, "version": bumpVersion}).Error; err != nil {
 			return err
 		}
-		if err := tx.Model(&job).Update("is_open", false).Error; err != nil {
+		locked.Status = status
+		locked.Version++
+		revision := model.NewJobRevision(locked, changedBy, reason)
+		if err := tx.Create(&revision).Error; err != nil {
 			return err
 		}
+		history := model.JobStatusHistories{
+			JobId:      job.ID,
+			FromStatus: job.Status,
+			ToStatus:   status,
+			ChangedBy:  changedBy,
+			Reason:     reason,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+		job = locked
 		return nil
 	})
 	return job, err
 }
 
-func (j *jobRepository) UpdateQuota(ctx context.Context, job model.Jobs, quota int) (model.Jobs, error) {
+func (j *jobRepository) FindStatusHistory(ctx context.Context, jobId int) ([]model.JobStatusHistories, error) {
+	histories := []model.JobStatusHistories{}
+
+	err := j.db.WithContext(ctx).
+		Model(&model.JobStatusHistories{}).
+		Where("job_id = ?", jobId).
+		Order("created_at ASC, id ASC").
+		Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (j *jobRepository) CloseExpired(ctx context.Context) ([]model.Jobs, error) {
+	return j.closeWhere(ctx, model.JobCloseReasonExpired, "expiry_date <= NOW()")
+}
+
+func (j *jobRepository) CloseFilled(ctx context.Context) ([]model.Jobs, error) {
+	return j.closeWhere(ctx, model.JobCloseReasonFilled, "quota <= 0")
+}
+
+// closeWhere closes every published or paused job matching condition and
+// records reason in its status history. Rows locked by another transaction
+// are skipped and picked up on the next run.
+func (j *jobRepository) closeWhere(ctx context.Context, reason string, condition string) ([]model.Jobs, error) {
+	jobs := []model.Jobs{}
+
 	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Jobs{}, job.ID).Error; err != nil {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status IN ?", []string{model.JobStatusPublished, model.JobStatusPaused}).
+			Where(condition).
+			Find(&jobs).Error
+		if err != nil || len(jobs) == 0 {
+			return err
+		}
+
+		ids := []uint{}
+		histories := []model.JobStatusHistories{}
+		revisions := []model.JobRevisions{}
+		for i, job := range jobs {
+			ids = append(ids, job.ID)
+			histories = append(histories, model.JobStatusHistories{
+				JobId:      job.ID,
+				FromStatus: job.Status,
+				ToStatus:   model.JobStatusClosed,
+				Reason:     reason,
+			})
+			jobs[i].Status = model.JobStatusClosed
+			jobs[i].Version++
+			revisions = append(revisions, model.NewJobRevision(jobs[i], 0, reason))
+		}
+
+		if err := tx.Model(&model.Jobs{}).Where("id IN ?", ids).Updates(map[string]interface{}{"status": model.JobStatusClosed, "version": bumpVersion}).Error; err != nil {
 			return err
 		}
-		if err := tx.Model(&model.Jobs{}).Where("id = ?", job.ID).Update("quota", quota).Error; err != nil {
+		if err := tx.Create(&histories).Error; err != nil {
 			return err
 		}
-		return nil
+		return tx.Create(&revisions).Error
 	})
-	return job, err
+	if err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
 }
 
-func (j *jobRepository) UpdateExpDate(ctx context.Context, job model.Jobs, expDate time.Time) (model.Jobs, error) {
+// Update sets fields of job, bumps its version and records the result as a
+// revision by changedBy. versions are the versions the caller's copy may
+// have; unless nil, a job at any other version was changed meanwhile and is
+// reported as ErrJobVersionMismatch. A job whose status is no longer that of
+// the caller's copy is reported as ErrJobStatusConflict.
+func (j *jobRepository) Update(ctx context.Context, job model.Jobs, fields map[string]interface{}, changedBy uint, versions []int) (model.Jobs, error) {
+	updated := model.Jobs{}
 	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Jobs{}, job.ID).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&updated, job.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrRecordNotFound
+			}
 			return err
 		}
-		if err := tx.Model(&model.Jobs{}).Where("id = ?", job.ID).Update("expiry_date", expDate).Error; err != nil {
+		if versions != nil && !containsVersion(versions, updated.Version) {
+			return shared.ErrJobVersionMismatch
+		}
+		if updated.Status != job.Status {
+			return shared.ErrJobStatusConflict
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+
+		changes := map[string]interface{}{"version": bumpVersion}
+		for column, value := range fields {
+			changes[column] = value
+		}
+		if err := tx.Model(&model.Jobs{}).Where("id = ?", job.ID).Updates(changes).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&updated, job.ID).Error; err != nil {
+			return err
+		}
+		revision := model.NewJobRevision(updated, changedBy, model.JobRevisionReasonEdited)
+		return tx.Create(&revision).Error
+	})
+	if err != nil {
+		return model.Jobs{}, err
+	}
+
+	return updated, nil
+}
+
+func (j *jobRepository) FindRevisions(ctx context.Context, jobId int, query dto.PaginationQuery) ([]model.JobRevisions, int64, error) {
+	revisions := []model.JobRevisions{}
+	var total int64
+
+	tx := j.db.WithContext(ctx).
+		Model(&model.JobRevisions{}).
+		Where("job_id = ?", jobId)
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Order("version ASC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&revisions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return revisions, total, nil
+}
+
+func (j *jobRepository) FindRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error) {
+	return j.findRevision(j.db.WithContext(ctx).Where("job_id = ? AND version = ?", jobId, version))
+}
+
+// FindPreviousRevision returns the revision of the job that came right
+// before version, or ErrRecordNotFound for its first one.
+func (j *jobRepository) FindPreviousRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error) {
+	return j.findRevision(j.db.WithContext(ctx).Where("job_id = ? AND version < ?", jobId, version).Order("version DESC"))
+}
+
+func (j *jobRepository) findRevision(tx *gorm.DB) (model.JobRevisions, error) {
+	revision := model.JobRevisions{}
+	if err := tx.First(&revision).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.JobRevisions{}, shared.ErrRecordNotFound
+		}
+		return model.JobRevisions{}, err
+	}
+
+	return revision, nil
+}
+
+func containsVersion(versions []int, version int) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *jobRepository) Delete(ctx context.Context, job model.Jobs) error {
+	res := j.db.WithContext(ctx).Delete(&model.Jobs{}, job.ID)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return shared.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (j *jobRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Jobs, int64, error) {
+	jobs := []model.Jobs{}
+	var total int64
+
+	tx := j.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Jobs{}).
+		Where("deleted_at IS NOT NULL")
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Order("deleted_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&jobs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return jobs, total, nil
+}
+
+// Restore undeletes the job. The poster may be gone, the job belongs to its
+// organization, but a job whose organization no longer exists cannot come
+// back.
+func (j *jobRepository) Restore(ctx context.Context, jobId int) (model.Jobs, error) {
+	job := model.Jobs{}
+
+	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", jobId).
+			First(&job).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrRecordNotFound
+			}
+			return err
+		}
+
+		if err := tx.First(&model.Organizations{}, job.OrganizationId).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrJobOrganizationMissing
+			}
 			return err
 		}
+
+		if err := tx.Unscoped().Model(&job).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		job.DeletedAt = gorm.DeletedAt{}
 		return nil
 	})
-	return job, err
+	if err != nil {
+		return model.Jobs{}, err
+	}
+
+	return job, nil
+}
+
+// PurgeDeleted hard deletes jobs deleted before the cutoff together with the
+// applications made to them. Status histories and revisions go with the rows
+// they belong to.
+func (j *jobRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := j.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		expired := tx.Unscoped().Model(&model.Jobs{}).Select("id").Where("deleted_at < ?", before)
+
+		err := tx.Unscoped().
+			Where("job_id IN (?)", expired).
+			Delete(&model.UserJobs{}).Error
+		if err != nil {
+			return err
+		}
+
+		res := tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.Jobs{})
+		purged = res.RowsAffected
+		return res.Error
+	})
+
+	return purged, err
 }
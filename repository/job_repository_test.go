@@ -0,0 +1,82 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobRepository_Update(t *testing.T) {
+	db := openTestDB(t)
+	jr := repository.NewJobRepository(db)
+	ujr := repository.NewUserJobRepository(db)
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "versioned",
+		Quota:          3,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	job, err := jr.Create(context.Background(), job, poster.ID)
+	require.NoError(t, err)
+	require.Equal(t, 1, job.Version)
+
+	updated, err := jr.Update(context.Background(), job, map[string]interface{}{"job_name": "renamed"}, poster.ID, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", updated.JobName)
+	assert.Equal(t, 2, updated.Version)
+
+	_, err = jr.Update(context.Background(), job, map[string]interface{}{"quota": 10}, poster.ID, []int{1})
+	assert.ErrorIs(t, err, shared.ErrJobVersionMismatch)
+
+	applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&applicant).Error)
+	_, err = ujr.Apply(context.Background(), applicant.ID, job.ID)
+	require.NoError(t, err)
+
+	// taking a slot of quota is a change the editor has not seen either
+	_, err = jr.Update(context.Background(), job, map[string]interface{}{"quota": 10}, poster.ID, []int{2})
+	assert.ErrorIs(t, err, shared.ErrJobVersionMismatch)
+
+	updated, err = jr.Update(context.Background(), job, map[string]interface{}{"quota": 10}, poster.ID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 10, updated.Quota)
+	assert.Equal(t, 4, updated.Version)
+
+	// applying took a slot of quota in a revision of its own, blamed on the
+	// applicant rather than on the poster's next edit
+	revisions, total, err := jr.FindRevisions(context.Background(), int(job.ID), dto.PaginationQuery{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	if assert.Len(t, revisions, 4) {
+		assert.Equal(t, []int{1, 2, 3, 4}, []int{revisions[0].Version, revisions[1].Version, revisions[2].Version, revisions[3].Version})
+		assert.Equal(t, model.JobRevisionReasonCreated, revisions[0].Reason)
+		assert.Equal(t, "versioned", revisions[0].JobName)
+		assert.Equal(t, "renamed", revisions[1].JobName)
+		assert.Equal(t, model.JobRevisionReasonApplied, revisions[2].Reason)
+		assert.Equal(t, applicant.ID, revisions[2].ChangedBy)
+		assert.Equal(t, 2, revisions[2].Quota)
+		assert.Equal(t, model.JobRevisionReasonEdited, revisions[3].Reason)
+		assert.Equal(t, 10, revisions[3].Quota)
+		assert.Equal(t, poster.ID, revisions[3].ChangedBy)
+	}
+
+	previous, err := jr.FindPreviousRevision(context.Background(), int(job.ID), 4)
+	require.NoError(t, err)
+	assert.Equal(t, 3, previous.Version)
+	_, err = jr.FindPreviousRevision(context.Background(), int(job.ID), 1)
+	assert.ErrorIs(t, err, shared.ErrRecordNotFound)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"gorm.io/gorm"
+)
+
+// jobDocument is the weighted text search document of a job. Keep it in
+// sync with the expression index on jobs so searches do not seq scan.
+const jobDocument = "setweight(to_tsvector('english', coalesce(job_name, '')), 'A') || " +
+	"setweight(to_tsvector('english', coalesce(job_desc, '')), 'B')"
+
+const (
+	nameHighlightOptions = "StartSel=<mark>, StopSel=</mark>, HighlightAll=true"
+	descSnippetOptions   = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2"
+)
+
+// Highlights are HTML, so the text is escaped before ts_headline adds the
+// <mark> tags; markup posted in a job must come out as text. The parser reads
+// the entities as such and still finds the words around them.
+var (
+	escapedJobName = escapeHTML("job_name")
+	escapedJobDesc = escapeHTML("job_desc")
+)
+
+// escapeHTML is the SQL for column with the characters html.EscapeString
+// escapes replaced by their entities.
+func escapeHTML(column string) string {
+	expr := "coalesce(" + column + ", '')"
+	for _, r := range [][2]string{{"&", "&amp;"}, {"<", "&lt;"}, {">", "&gt;"}, {`"`, "&#34;"}, {"''", "&#39;"}} {
+		expr = "replace(" + expr + ", '" + r[0] + "', '" + r[1] + "')"
+	}
+	return expr
+}
+
+type JobSearchHit struct {
+	ID            uint
+	JobPosterId   uint
+	JobName       string
+	JobDesc       string
+	Quota         int
+	Rank          float64
+	NameHighlight string
+	DescSnippet   string
+}
+
+type jobSearcher struct {
+	db *gorm.DB
+}
+
+type JobSearcher interface {
+	Search(ctx context.Context, query dto.JobSearchQuery) ([]JobSearchHit, int64, error)
+}
+
+func NewJobSearcher(db *gorm.DB) JobSearcher {
+	return &jobSearcher{
+		db: db,
+	}
+}
+
+func (s *jobSearcher) Search(ctx context.Context, query dto.JobSearchQuery) ([]JobSearchHit, int64, error) {
+	hits := []JobSearchHit{}
+	var total int64
+
+	tsQuery := ToTSQuery(query.Q)
+	if tsQuery == "" {
+		return nil, 0, shared.ErrInvalidSearchQuery
+	}
+
+	err := s.db.WithContext(ctx).
+		Raw("SELECT COUNT(*) FROM jobs WHERE "+jobDocument+" @@ to_tsquery('english', ?) AND expiry_date > NOW() AND status = ? AND deleted_at IS NULL", tsQuery, model.JobStatusPublished).
+		Scan(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = s.db.WithContext(ctx).
+		Raw(`SELECT id, job_poster_id, job_name, job_desc, quota,
+			ts_rank(`+jobDocument+`, q) AS rank,
+			ts_headline('english', `+escapedJobName+`, q, ?) AS name_highlight,
+			ts_headline('english', `+escapedJobDesc+`, q, ?) AS desc_snippet
+		FROM jobs, to_tsquery('english', ?) q
+		WHERE `+jobDocument+` @@ q AND expiry_date > NOW() AND status = ? AND deleted_at IS NULL
+		ORDER BY rank DESC, id ASC
+		LIMIT ? OFFSET ?`,
+			nameHighlightOptions, descSnippetOptions, tsQuery, model.JobStatusPublished, query.Limit, query.Offset()).
+		Scan(&hits).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return hits, total, nil
+}
+
+// ToTSQuery turns user input into a to_tsquery expression. Double quoted
+// text becomes a phrase, a trailing * makes a prefix match and every other
+// word is required. Anything that is not a letter or digit is dropped, so the
+// result is always safe to hand to Postgres.
+func ToTSQuery(input string) string {
+	terms := []string{}
+
+	for i, part := range strings.Split(input, `"`) {
+		if i%2 == 1 {
+			if phrase := tsPhrase(strings.Fields(part)); phrase != "" {
+				terms = append(terms, phrase)
+			}
+			continue
+		}
+		for _, word := range strings.Fields(part) {
+			if phrase := tsPhrase([]string{word}); phrase != "" {
+				terms = append(terms, phrase)
+			}
+		}
+	}
+
+	return strings.Join(terms, " & ")
+}
+
+func tsPhrase(words []string) string {
+	lexemes := []string{}
+
+	for _, word := range words {
+		prefix := strings.HasSuffix(word, "*")
+		parts := strings.FieldsFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for i, part := range parts {
+			lexeme := strings.ToLower(part)
+			if prefix && i == len(parts)-1 {
+				lexeme += ":*"
+			}
+			lexemes = append(lexemes, lexeme)
+		}
+	}
+
+	return strings.Join(lexemes, " <-> ")
+}
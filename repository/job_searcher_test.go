@@ -0,0 +1,65 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToTSQuery(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "golang", expected: "golang"},
+		{input: "Golang Backend", expected: "golang & backend"},
+		{input: `"backend engineer" remote`, expected: "backend <-> engineer & remote"},
+		{input: "eng*", expected: "eng:*"},
+		{input: `"senior dev*"`, expected: "senior <-> dev:*"},
+		{input: "full-time", expected: "full <-> time"},
+		{input: "'; DROP TABLE jobs; --", expected: "drop & table & jobs"},
+		{input: `"" * !`, expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			assert.Equal(t, tt.expected, repository.ToTSQuery(tt.input))
+		})
+	}
+}
+
+func TestJobSearcher_SearchEscapesHighlights(t *testing.T) {
+	db := openTestDB(t)
+	js := repository.NewJobSearcher(db)
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	word := fmt.Sprintf("xss%d", time.Now().UnixNano())
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        word + ` <img src=x onerror="alert(1)">`,
+		JobDesc:        "<script>alert('" + word + "')</script> & more",
+		Quota:          1,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	hits, _, err := js.Search(context.Background(), dto.JobSearchQuery{PaginationQuery: dto.PaginationQuery{Page: 1, Limit: 10}, Q: word})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Contains(t, hits[0].NameHighlight, "<mark>"+word+"</mark>")
+	assert.NotContains(t, hits[0].NameHighlight, "<img")
+	assert.Contains(t, hits[0].NameHighlight, "&lt;img src=x onerror=&#34;alert(1)&#34;&gt;")
+	assert.NotContains(t, hits[0].DescSnippet, "<script>")
+	assert.Contains(t, hits[0].DescSnippet, "&lt;script&gt;")
+}
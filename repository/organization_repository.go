@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrganizationRepository interface {
+	Create(ctx context.Context, organization model.Organizations, createdBy uint) (model.Organizations, error)
+	FindById(ctx context.Context, id uint) (model.Organizations, error)
+	FindByMember(ctx context.Context, userId uint) ([]model.OrganizationMembers, error)
+	FindMembership(ctx context.Context, organizationId uint, userId uint) (model.OrganizationMembers, error)
+	FindMembers(ctx context.Context, organizationId uint) ([]model.OrganizationMembers, error)
+	AddMember(ctx context.Context, member model.OrganizationMembers) (model.OrganizationMembers, error)
+	UpdateMemberRole(ctx context.Context, organizationId uint, userId uint, role string) (model.OrganizationMembers, error)
+	RemoveMember(ctx context.Context, organizationId uint, userId uint) error
+}
+
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{
+		db: db,
+	}
+}
+
+// Create stores organization and makes createdBy its first owner.
+func (o *organizationRepository) Create(ctx context.Context, organization model.Organizations, createdBy uint) (model.Organizations, error) {
+	organization.CreatedBy = &createdBy
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&organization).Error; err != nil {
+			return err
+		}
+		owner := model.OrganizationMembers{
+			OrganizationId: organization.ID,
+			UserId:         createdBy,
+			Role:           model.OrganizationRoleOwner,
+		}
+		return tx.Create(&owner).Error
+	})
+	if err != nil {
+		return model.Organizations{}, err
+	}
+
+	return organization, nil
+}
+
+func (o *organizationRepository) FindById(ctx context.Context, id uint) (model.Organizations, error) {
+	organization := model.Organizations{}
+	if err := o.db.WithContext(ctx).First(&organization, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Organizations{}, shared.ErrRecordNotFound
+		}
+		return model.Organizations{}, err
+	}
+
+	return organization, nil
+}
+
+// FindByMember lists the memberships of a user with their organizations.
+func (o *organizationRepository) FindByMember(ctx context.Context, userId uint) ([]model.OrganizationMembers, error) {
+	memberships := []model.OrganizationMembers{}
+
+	err := o.db.WithContext(ctx).
+		Preload("Organization").
+		Where("user_id = ?", userId).
+		Order("organization_id ASC").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return memberships, nil
+}
+
+func (o *organizationRepository) FindMembership(ctx context.Context, organizationId uint, userId uint) (model.OrganizationMembers, error) {
+	member := model.OrganizationMembers{}
+
+	err := o.db.WithContext(ctx).
+		Where("organization_id = ? AND user_id = ?", organizationId, userId).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OrganizationMembers{}, shared.ErrRecordNotFound
+		}
+		return model.OrganizationMembers{}, err
+	}
+
+	return member, nil
+}
+
+func (o *organizationRepository) FindMembers(ctx context.Context, organizationId uint) ([]model.OrganizationMembers, error) {
+	members := []model.OrganizationMembers{}
+
+	err := o.db.WithContext(ctx).
+		Preload("User").
+		Where("organization_id = ?", organizationId).
+		Order("created_at ASC, id ASC").
+		Find(&members).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (o *organizationRepository) AddMember(ctx context.Context, member model.OrganizationMembers) (model.OrganizationMembers, error) {
+	if err := o.db.WithContext(ctx).Create(&member).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return model.OrganizationMembers{}, shared.ErrAlreadyMember
+		}
+		return model.OrganizationMembers{}, err
+	}
+
+	return member, nil
+}
+
+// UpdateMemberRole changes the role of a member. Demoting the last owner is
+// refused with ErrLastOwner.
+func (o *organizationRepository) UpdateMemberRole(ctx context.Context, organizationId uint, userId uint, role string) (model.OrganizationMembers, error) {
+	member := model.OrganizationMembers{}
+
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		member, err = lockMembership(tx, organizationId, userId)
+		if err != nil {
+			return err
+		}
+		if member.Role == model.OrganizationRoleOwner && role != model.OrganizationRoleOwner {
+			if err := keepAnOwner(tx, organizationId); err != nil {
+				return err
+			}
+		}
+
+		member.Role = role
+		return tx.Model(&member).Update("role", role).Error
+	})
+	if err != nil {
+		return model.OrganizationMembers{}, err
+	}
+
+	return member, nil
+}
+
+// RemoveMember takes a user out of an organization. Removing the last owner
+// is refused with ErrLastOwner.
+func (o *organizationRepository) RemoveMember(ctx context.Context, organizationId uint, userId uint) error {
+	return o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		member, err := lockMembership(tx, organizationId, userId)
+		if err != nil {
+			return err
+		}
+		if member.Role == model.OrganizationRoleOwner {
+			if err := keepAnOwner(tx, organizationId); err != nil {
+				return err
+			}
+		}
+
+		return tx.Delete(&member).Error
+	})
+}
+
+func lockMembership(tx *gorm.DB, organizationId uint, userId uint) (model.OrganizationMembers, error) {
+	member := model.OrganizationMembers{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND user_id = ?", organizationId, userId).
+		First(&member).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OrganizationMembers{}, shared.ErrRecordNotFound
+		}
+		return model.OrganizationMembers{}, err
+	}
+
+	return member, nil
+}
+
+// keepAnOwner fails with ErrLastOwner unless the organization has another
+// owner besides the one about to go. The owners are locked, so two owners
+// cannot step down at the same time and leave none.
+func keepAnOwner(tx *gorm.DB, organizationId uint) error {
+	owners := []model.OrganizationMembers{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("organization_id = ? AND role = ?", organizationId, model.OrganizationRoleOwner).
+		Find(&owners).Error
+	if err != nil {
+		return err
+	}
+	if len(owners) < 2 {
+		return shared.ErrLastOwner
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// createOrganization creates an organization owned by owner for the jobs of
+// a test.
+func createOrganization(t *testing.T, db *gorm.DB, owner model.Users) model.Organizations {
+	organization, err := repository.NewOrganizationRepository(db).Create(context.Background(), model.Organizations{Name: "organization"}, owner.ID)
+	require.NoError(t, err)
+	return organization
+}
+
+func TestOrganizationRepository_Members(t *testing.T) {
+	db := openTestDB(t)
+	or := repository.NewOrganizationRepository(db)
+	ctx := context.Background()
+
+	owner := model.Users{Name: "owner", Email: fmt.Sprintf("owner-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&owner).Error)
+	recruiter := model.Users{Name: "recruiter", Email: fmt.Sprintf("recruiter-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&recruiter).Error)
+	organization := createOrganization(t, db, owner)
+
+	membership, err := or.FindMembership(ctx, organization.ID, owner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.OrganizationRoleOwner, membership.Role)
+
+	_, err = or.AddMember(ctx, model.OrganizationMembers{OrganizationId: organization.ID, UserId: recruiter.ID, Role: model.OrganizationRoleRecruiter})
+	require.NoError(t, err)
+	_, err = or.AddMember(ctx, model.OrganizationMembers{OrganizationId: organization.ID, UserId: recruiter.ID, Role: model.OrganizationRoleAdmin})
+	assert.ErrorIs(t, err, shared.ErrAlreadyMember)
+
+	_, err = or.UpdateMemberRole(ctx, organization.ID, owner.ID, model.OrganizationRoleAdmin)
+	assert.ErrorIs(t, err, shared.ErrLastOwner)
+	assert.ErrorIs(t, or.RemoveMember(ctx, organization.ID, owner.ID), shared.ErrLastOwner)
+
+	_, err = or.UpdateMemberRole(ctx, organization.ID, recruiter.ID, model.OrganizationRoleOwner)
+	require.NoError(t, err)
+	require.NoError(t, or.RemoveMember(ctx, organization.ID, owner.ID))
+
+	members, err := or.FindMembers(ctx, organization.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.Equal(t, recruiter.ID, members[0].UserId)
+	assert.Equal(t, "recruiter", members[0].User.Name)
+}
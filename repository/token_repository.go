@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+type TokenRepository interface {
+	CreateRefreshToken(ctx context.Context, token model.RefreshTokens) (model.RefreshTokens, error)
+	FindRefreshTokenByHash(ctx context.Context, hash string) (model.RefreshTokens, error)
+	RotateRefreshToken(ctx context.Context, current model.RefreshTokens, next model.RefreshTokens) (model.RefreshTokens, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string) error
+	RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error)
+	CreateUserToken(ctx context.Context, token model.UserTokens) (model.UserTokens, error)
+	PurgeExpired(ctx context.Context, before time.Time) (int64, error)
+}
+
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{
+		db: db,
+	}
+}
+
+func (t *tokenRepository) CreateRefreshToken(ctx context.Context, token model.RefreshTokens) (model.RefreshTokens, error) {
+	err := t.db.WithContext(ctx).Create(&token).Error
+	if err != nil {
+		return model.RefreshTokens{}, err
+	}
+
+	return token, nil
+}
+
+func (t *tokenRepository) FindRefreshTokenByHash(ctx context.Context, hash string) (model.RefreshTokens, error) {
+	token := model.RefreshTokens{}
+
+	err := t.db.WithContext(ctx).
+		Model(&model.RefreshTokens{}).
+		Where("token_hash = ?", hash).
+		First(&token).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.RefreshTokens{}, shared.ErrRecordNotFound
+		}
+		return model.RefreshTokens{}, err
+	}
+
+	return token, nil
+}
+
+// RotateRefreshToken marks current as used and stores next in its place. If
+// current was already used or revoked the token is being replayed, so the
+// whole family is revoked and ErrRefreshTokenReused is returned.
+func (t *tokenRepository) RotateRefreshToken(ctx context.Context, current model.RefreshTokens, next model.RefreshTokens) (model.RefreshTokens, error) {
+	reused := false
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked := model.RefreshTokens{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, current.ID).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if locked.RevokedAt != nil {
+			reused = true
+			return tx.Model(&model.RefreshTokens{}).
+				Where("family_id = ? AND revoked_at IS NULL", locked.FamilyId).
+				Update("revoked_at", now).Error
+		}
+
+		if err := tx.Model(&locked).Update("revoked_at", now).Error; err != nil {
+			return err
+		}
+		return tx.Create(&next).Error
+	})
+	if err != nil {
+		return model.RefreshTokens{}, err
+	}
+	if reused {
+		return model.RefreshTokens{}, shared.ErrRefreshTokenReused
+	}
+
+	return next, nil
+}
+
+func (t *tokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	return t.db.WithContext(ctx).
+		Model(&model.RefreshTokens{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyId).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (t *tokenRepository) RevokeAccessToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	revoked := model.RevokedTokens{
+		Jti:       jti,
+		ExpiresAt: expiresAt,
+	}
+
+	return t.db.WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&revoked).Error
+}
+
+// IsAccessTokenRevoked reports whether the access token was revoked or the
+// user it was issued to has been deleted since.
+func (t *tokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error) {
+	var revoked bool
+
+	err := t.db.WithContext(ctx).
+		Raw(`SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = ?)
+			OR NOT EXISTS (SELECT 1 FROM users WHERE id = ? AND deleted_at IS NULL)`, jti, userId).
+		Scan(&revoked).Error
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// CreateUserToken stores token and invalidates the user's earlier unused
+// tokens for the same purpose, so only the latest link works.
+func (t *tokenRepository) CreateUserToken(ctx context.Context, token model.UserTokens) (model.UserTokens, error) {
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&model.UserTokens{}).
+			Where("user_id = ? AND purpose = ? AND used_at IS NULL", token.UserId, token.Purpose).
+			Update("used_at", time.Now()).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(&token).Error
+	})
+	if err != nil {
+		return model.UserTokens{}, err
+	}
+
+	return token, nil
+}
+
+// PurgeExpired deletes the revoked access tokens, refresh tokens and user
+// tokens that expired before the cutoff. An expired token is refused anyway,
+// so nothing needs to remember it.
+func (t *tokenRepository) PurgeExpired(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, expired := range []interface{}{&model.RevokedTokens{}, &model.RefreshTokens{}, &model.UserTokens{}} {
+			res := tx.Where("expires_at < ?", before).Delete(expired)
+			if res.Error != nil {
+				return res.Error
+			}
+			purged += res.RowsAffected
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
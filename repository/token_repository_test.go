@@ -0,0 +1,53 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenRepository_IsAccessTokenRevoked(t *testing.T) {
+	db := openTestDB(t)
+	tr := repository.NewTokenRepository(db)
+	ur := repository.NewUserRepository(db)
+	ctx := context.Background()
+
+	user := model.Users{Name: "user", Email: fmt.Sprintf("user-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&user).Error)
+	jti := fmt.Sprintf("jti-%d", time.Now().UnixNano())
+
+	revoked, err := tr.IsAccessTokenRevoked(ctx, jti, user.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// the tokens of a deleted user stop working before they expire
+	require.NoError(t, ur.Delete(ctx, user.ID))
+	revoked, err = tr.IsAccessTokenRevoked(ctx, jti, user.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
+
+func TestTokenRepository_PurgeExpired(t *testing.T) {
+	db := openTestDB(t)
+	tr := repository.NewTokenRepository(db)
+	ctx := context.Background()
+
+	expired := fmt.Sprintf("expired-%d", time.Now().UnixNano())
+	live := fmt.Sprintf("live-%d", time.Now().UnixNano())
+	require.NoError(t, tr.RevokeAccessToken(ctx, expired, time.Now().Add(-time.Hour)))
+	require.NoError(t, tr.RevokeAccessToken(ctx, live, time.Now().Add(time.Hour)))
+
+	purged, err := tr.PurgeExpired(ctx, time.Now())
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	var left []string
+	require.NoError(t, db.Model(&model.RevokedTokens{}).Where("jti IN ?", []string{expired, live}).Pluck("jti", &left).Error)
+	assert.Equal(t, []string{live}, left)
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -13,10 +17,18 @@ type userJobRepository struct {
 }
 
 type UserJobRepository interface {
-	FindById(ctx context.Context, jobId int) (model.Jobs, error)
-	Create(ctx context.Context, newApply model.UserJobs) (model.UserJobs, error)
-	UpdateMinusOneQuota(ctx context.Context, job model.Jobs) (model.Jobs, error)
+	Apply(ctx context.Context, userId uint, jobId uint) (model.UserJobs, error)
 	FindByJobIdUserId(ctx context.Context, jobId int, userId int) ([]model.UserJobs, error)
+	FindByUserId(ctx context.Context, userId uint, query dto.ApplicationsQuery) ([]model.UserJobs, int64, error)
+	FindByIdUserId(ctx context.Context, id int, userId uint) (model.UserJobs, error)
+	Withdraw(ctx context.Context, application model.UserJobs) (model.UserJobs, error)
+	FindByIdJobId(ctx context.Context, id int, jobId int) (model.UserJobs, error)
+	FindByJobId(ctx context.Context, jobId int, query dto.ApplicantsQuery) ([]model.UserJobs, int64, error)
+	UpdateStatus(ctx context.Context, application model.UserJobs, status string, changedBy uint, note string) (model.UserJobs, error)
+	FindStatusHistory(ctx context.Context, applicationId int) ([]model.ApplicationStatusHistories, error)
+	FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.UserJobs, int64, error)
+	Restore(ctx context.Context, id int, restoredBy uint) (model.UserJobs, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
 func NewUserJobRepository(db *gorm.DB) UserJobRepository {
@@ -25,18 +37,57 @@ func NewUserJobRepository(db *gorm.DB) UserJobRepository {
 	}
 }
 
-func (j *userJobRepository) FindById(ctx context.Context, jobId int) (model.Jobs, error) {
-	job := model.Jobs{}
+// Apply locks the job row, checks it can still take applicants, inserts the
+// application and takes one slot of quota in a single transaction, so
+// concurrent applicants can never push quota below zero.
+func (uj *userJobRepository) Apply(ctx context.Context, userId uint, jobId uint) (model.UserJobs, error) {
+	newApply := model.UserJobs{
+		UserId: userId,
+		JobId:  jobId,
+		Status: model.ApplicationStatusApplied,
+	}
 
-	err := j.db.WithContext(ctx).
-		Model(&model.Jobs{}).
-		Where("id = ? AND expiry_date > NOW() AND is_open IS TRUE", jobId).
-		First(&job).Error
+	err := uj.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkApplicant(tx, userId); err != nil {
+			return err
+		}
+		if _, err := lockOpenJob(tx, jobId); err != nil {
+			return err
+		}
+
+		var applied int64
+		err := tx.Model(&model.UserJobs{}).
+			Where("job_id = ? AND user_id = ?", jobId, userId).
+			Count(&applied).Error
+		if err != nil {
+			return err
+		}
+		if applied != 0 {
+			return shared.ErrAlreadyApplied
+		}
+
+		if err := tx.Create(&newApply).Error; err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return shared.ErrAlreadyApplied
+			}
+			return err
+		}
+		history := model.ApplicationStatusHistories{
+			ApplicationId: newApply.ID,
+			ToStatus:      newApply.Status,
+			ChangedBy:     userId,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		return changeQuota(tx, jobId, -1, userId, model.JobRevisionReasonApplied)
+	})
 	if err != nil {
-		return model.Jobs{}, err
+		return model.UserJobs{}, err
 	}
 
-	return job, nil
+	return newApply, nil
 }
 
 func (uj *userJobRepository) FindByJobIdUserId(ctx context.Context, jobId int, userId int) ([]model.UserJobs, error) {
@@ -53,24 +104,433 @@ func (uj *userJobRepository) FindByJobIdUserId(ctx context.Context, jobId int, u
 	return jobs, nil
 }
 
-func (uj *userJobRepository) Create(ctx context.Context, newApply model.UserJobs) (model.UserJobs, error) {
-	err := uj.db.WithContext(ctx).Model(&model.UserJobs{}).Create(&newApply).Error
+func (uj *userJobRepository) FindByUserId(ctx context.Context, userId uint, query dto.ApplicationsQuery) ([]model.UserJobs, int64, error) {
+	applications := []model.UserJobs{}
+	var total int64
+
+	tx := uj.db.WithContext(ctx).
+		Model(&model.UserJobs{}).
+		Where("user_id = ?", userId)
+
+	if query.Status != "" {
+		tx = tx.Where("status = ?", query.Status)
+	}
+	// withdrawn applications are soft deleted but still belong to the
+	// applicant's history, so they are listed when asked for explicitly.
+	if query.Status == model.ApplicationStatusWithdrawn {
+		tx = tx.Unscoped()
+	}
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Preload("Jobs").
+		Order("created_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&applications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+func (uj *userJobRepository) FindByIdUserId(ctx context.Context, id int, userId uint) (model.UserJobs, error) {
+	application := model.UserJobs{}
+
+	err := uj.db.WithContext(ctx).
+		Model(&model.UserJobs{}).
+		Preload("Jobs").
+		Where("id = ? AND user_id = ?", id, userId).
+		First(&application).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.UserJobs{}, shared.ErrRecordNotFound
+		}
 		return model.UserJobs{}, err
 	}
 
-	return newApply, nil
+	return application, nil
 }
 
-func (uj *userJobRepository) UpdateMinusOneQuota(ctx context.Context, job model.Jobs) (model.Jobs, error) {
+// Withdraw soft deletes the application and gives its slot back to the job.
+// The application must still have the status it was read with, otherwise a
+// concurrent pipeline change wins and ErrApplicationStatusConflict is returned.
+func (uj *userJobRepository) Withdraw(ctx context.Context, application model.UserJobs) (model.UserJobs, error) {
 	err := uj.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Jobs{}, job.ID).Error; err != nil {
+		locked := model.UserJobs{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, application.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrApplicationNotFound
+			}
 			return err
 		}
-		if err := tx.Model(&model.Jobs{}).Where("id = ?", job.ID).Update("quota", gorm.Expr("quota-1")).Error; err != nil {
+		if locked.Status != application.Status {
+			return shared.ErrApplicationStatusConflict
+		}
+
+		if err := withdraw(tx, &locked, ""); err != nil {
 			return err
 		}
+
+		application.Status = model.ApplicationStatusWithdrawn
+		application.UpdatedAt = locked.UpdatedAt
 		return nil
 	})
-	return job, err
+	if err != nil {
+		return model.UserJobs{}, err
+	}
+
+	return application, nil
+}
+
+func (uj *userJobRepository) FindByIdJobId(ctx context.Context, id int, jobId int) (model.UserJobs, error) {
+	application := model.UserJobs{}
+
+	err := uj.db.WithContext(ctx).
+		Model(&model.UserJobs{}).
+		Preload("Jobs").
+		Where("id = ? AND job_id = ?", id, jobId).
+		First(&application).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.UserJobs{}, shared.ErrRecordNotFound
+		}
+		return model.UserJobs{}, err
+	}
+
+	return application, nil
+}
+
+func (uj *userJobRepository) FindByJobId(ctx context.Context, jobId int, query dto.ApplicantsQuery) ([]model.UserJobs, int64, error) {
+	applications := []model.UserJobs{}
+	var total int64
+
+	tx := uj.db.WithContext(ctx).
+		Model(&model.UserJobs{}).
+		Where("job_id = ?", jobId)
+
+	if query.Status != "" {
+		tx = tx.Where("status = ?", query.Status)
+	}
+	if query.Status == model.ApplicationStatusWithdrawn {
+		tx = tx.Unscoped()
+	}
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order := "created_at DESC, id DESC"
+	if query.Sort == dto.SortApplicantsOldest {
+		order = "created_at ASC, id ASC"
+	}
+
+	err := tx.Preload("Users").
+		Order(order).
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&applications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+func (uj *userJobRepository) UpdateStatus(ctx context.Context, application model.UserJobs, status string, changedBy uint, note string) (model.UserJobs, error) {
+	err := uj.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		locked := model.UserJobs{}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&locked, application.ID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrApplicationNotFound
+			}
+			return err
+		}
+		if locked.Status != application.Status {
+			return shared.ErrApplicationStatusConflict
+		}
+
+		if err := tx.Model(&locked).Update("status", status).Error; err != nil {
+			return err
+		}
+		history := model.ApplicationStatusHistories{
+			ApplicationId: locked.ID,
+			FromStatus:    application.Status,
+			ToStatus:      status,
+			ChangedBy:     changedBy,
+			Note:          note,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		application.Status = status
+		application.UpdatedAt = locked.UpdatedAt
+		return nil
+	})
+	if err != nil {
+		return model.UserJobs{}, err
+	}
+
+	return application, nil
+}
+
+func (uj *userJobRepository) FindStatusHistory(ctx context.Context, applicationId int) ([]model.ApplicationStatusHistories, error) {
+	histories := []model.ApplicationStatusHistories{}
+
+	err := uj.db.WithContext(ctx).
+		Model(&model.ApplicationStatusHistories{}).
+		Where("application_id = ?", applicationId).
+		Order("created_at ASC, id ASC").
+		Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return histories, nil
+}
+
+func (uj *userJobRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.UserJobs, int64, error) {
+	applications := []model.UserJobs{}
+	var total int64
+
+	tx := uj.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.UserJobs{}).
+		Where("deleted_at IS NOT NULL")
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Order("deleted_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&applications).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return applications, total, nil
+}
+
+// Restore undoes a withdrawal: the application gets back the status it was
+// withdrawn from and takes a slot of the job's quota again, in one
+// transaction like Apply and under the same checks on the job and the
+// applicant.
+func (uj *userJobRepository) Restore(ctx context.Context, id int, restoredBy uint) (model.UserJobs, error) {
+	application := model.UserJobs{}
+
+	err := uj.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			First(&application).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return shared.ErrApplicationNotFound
+			}
+			return err
+		}
+
+		withdrawal := model.ApplicationStatusHistories{}
+		err = tx.Where("application_id = ? AND to_status = ?", application.ID, model.ApplicationStatusWithdrawn).
+			Order("id DESC").
+			Limit(1).
+			Find(&withdrawal).Error
+		if err != nil {
+			return err
+		}
+		status := withdrawal.FromStatus
+		if status == "" {
+			status = model.ApplicationStatusApplied
+		}
+
+		if err := checkApplicant(tx, application.UserId); err != nil {
+			return err
+		}
+		job, err := lockOpenJob(tx, application.JobId)
+		if err != nil {
+			return err
+		}
+
+		err = tx.Unscoped().
+			Model(&model.UserJobs{}).
+			Where("id = ?", application.ID).
+			Updates(map[string]interface{}{"deleted_at": nil, "status": status}).Error
+		if err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				return shared.ErrAlreadyApplied
+			}
+			return err
+		}
+		history := model.ApplicationStatusHistories{
+			ApplicationId: application.ID,
+			FromStatus:    application.Status,
+			ToStatus:      status,
+			ChangedBy:     restoredBy,
+			Note:          "restored",
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		application.Status = status
+		application.DeletedAt = gorm.DeletedAt{}
+		return changeQuota(tx, job.ID, -1, restoredBy, model.JobRevisionReasonApplicationRestored)
+	})
+	if err != nil {
+		return model.UserJobs{}, err
+	}
+
+	return application, nil
+}
+
+// PurgeDeleted hard deletes applications deleted before the cutoff and every
+// application of users deleted before it, so the users can be purged next.
+func (uj *userJobRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	expiredUsers := uj.db.Unscoped().Model(&model.Users{}).Select("id").Where("deleted_at < ?", before)
+
+	res := uj.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at < ? OR user_id IN (?)", before, expiredUsers).
+		Delete(&model.UserJobs{})
+
+	return res.RowsAffected, res.Error
+}
+
+// lockOpenJob locks a job and checks it still takes applicants: it is
+// published, not past its expiry date and has quota left.
+func lockOpenJob(tx *gorm.DB, jobId uint) (model.Jobs, error) {
+	job := model.Jobs{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", jobId).
+		First(&job).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Jobs{}, shared.ErrJobNotFound
+		}
+		return model.Jobs{}, err
+	}
+	if job.Status != model.JobStatusPublished || !job.ExpiryDate.After(time.Now()) {
+		return model.Jobs{}, shared.ErrJobNotFound
+	}
+	if job.Quota <= 0 {
+		return model.Jobs{}, shared.ErrJobFull
+	}
+
+	return job, nil
+}
+
+// checkApplicant fails with ErrUserNotFound when the applicant was deleted,
+// so a deleted account never holds a slot of quota.
+func checkApplicant(tx *gorm.DB, userId uint) error {
+	err := tx.Select("id").First(&model.Users{}, userId).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return shared.ErrUserNotFound
+	}
+	return err
+}
+
+// changeQuota moves the quota of a job the transaction has locked by delta
+// and records the revision that makes, so quota taken or given back by
+// applications is not blamed on whoever changes the job next.
+func changeQuota(tx *gorm.DB, jobId uint, delta int, changedBy uint, reason string) error {
+	err := tx.Unscoped().
+		Model(&model.Jobs{}).
+		Where("id = ?", jobId).
+		Updates(map[string]interface{}{"quota": gorm.Expr("quota + ?", delta), "version": bumpVersion}).Error
+	if err != nil {
+		return err
+	}
+
+	job := model.Jobs{}
+	if err := tx.Unscoped().First(&job, jobId).Error; err != nil {
+		return err
+	}
+	revision := model.NewJobRevision(job, changedBy, reason)
+	return tx.Create(&revision).Error
+}
+
+// withdraw soft deletes an application the transaction has locked and gives
+// its slot back to the job, reopening the job if it was closed for lack of
+// one.
+func withdraw(tx *gorm.DB, application *model.UserJobs, note string) error {
+	from := application.Status
+	if err := tx.Model(application).Update("status", model.ApplicationStatusWithdrawn).Error; err != nil {
+		return err
+	}
+	if err := tx.Delete(application).Error; err != nil {
+		return err
+	}
+	history := model.ApplicationStatusHistories{
+		ApplicationId: application.ID,
+		FromStatus:    from,
+		ToStatus:      model.ApplicationStatusWithdrawn,
+		ChangedBy:     application.UserId,
+		Note:          note,
+	}
+	if err := tx.Create(&history).Error; err != nil {
+		return err
+	}
+
+	// the job may have been deleted since, its quota is still restored in
+	// case it is brought back.
+	err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.Jobs{}, application.JobId).Error
+	if err != nil {
+		return err
+	}
+	if err := changeQuota(tx, application.JobId, 1, application.UserId, model.JobRevisionReasonWithdrawn); err != nil {
+		return err
+	}
+	return reopenFilled(tx, application.JobId, application.UserId)
+}
+
+// reopenFilled puts a job the transaction has locked back to the status it
+// was in before it was closed as quota_filled. A job closed for any other
+// reason, past its expiry date or deleted stays closed.
+func reopenFilled(tx *gorm.DB, jobId uint, changedBy uint) error {
+	job := model.Jobs{}
+	if err := tx.Unscoped().First(&job, jobId).Error; err != nil {
+		return err
+	}
+	if job.Status != model.JobStatusClosed || job.DeletedAt.Valid || !job.ExpiryDate.After(time.Now()) || job.Quota <= 0 {
+		return nil
+	}
+
+	closed := model.JobStatusHistories{}
+	err := tx.Where("job_id = ? AND to_status = ?", jobId, model.JobStatusClosed).
+		Order("created_at DESC, id DESC").
+		First(&closed).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if closed.Reason != model.JobCloseReasonFilled {
+		return nil
+	}
+
+	if err := tx.Model(&job).Updates(map[string]interface{}{"status": closed.FromStatus, "version": bumpVersion}).Error; err != nil {
+		return err
+	}
+	job.Status = closed.FromStatus
+	job.Version++
+	revision := model.NewJobRevision(job, changedBy, model.JobReopenReasonQuotaReturned)
+	if err := tx.Create(&revision).Error; err != nil {
+		return err
+	}
+	history := model.JobStatusHistories{
+		JobId:      jobId,
+		FromStatus: model.JobStatusClosed,
+		ToStatus:   closed.FromStatus,
+		ChangedBy:  changedBy,
+		Reason:     model.JobReopenReasonQuotaReturned,
+	}
+	return tx.Create(&history).Error
 }
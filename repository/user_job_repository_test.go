@@ -0,0 +1,304 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/migration"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB connects to TEST_DATABASE_URL and migrates it to the latest
+// schema, so it must point at a database owned by the migrations. Tests that
+// need a real Postgres are skipped when it is not set.
+func openTestDB(t *testing.T) *gorm.DB {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent), TranslateError: true})
+	require.NoError(t, err)
+	migrations, err := migration.Embedded()
+	require.NoError(t, err)
+	_, err = migration.NewMigrator(db, migrations).Up(context.Background())
+	require.NoError(t, err)
+
+	return db
+}
+
+func TestUserJobRepository_Apply(t *testing.T) {
+	db := openTestDB(t)
+	ujr := repository.NewUserJobRepository(db)
+
+	const quota = 5
+	const applicants = 40
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "concurrency",
+		Quota:          quota,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	users := make([]model.Users, applicants)
+	for i := range users {
+		users[i] = model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d-%d@test.local", i, time.Now().UnixNano())}
+	}
+	require.NoError(t, db.Create(&users).Error)
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted, full := 0, 0
+	for _, u := range users {
+		wg.Add(1)
+		go func(userId uint) {
+			defer wg.Done()
+			_, err := ujr.Apply(context.Background(), userId, job.ID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				accepted++
+			case errors.Is(err, shared.ErrJobFull):
+				full++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(u.ID)
+	}
+	wg.Wait()
+
+	var count int64
+	require.NoError(t, db.Model(&model.UserJobs{}).Where("job_id = ?", job.ID).Count(&count).Error)
+	require.NoError(t, db.First(&job, job.ID).Error)
+
+	assert.Equal(t, quota, accepted)
+	assert.Equal(t, applicants-quota, full)
+	assert.Equal(t, int64(quota), count)
+	assert.Equal(t, 0, job.Quota)
+
+	_, err := ujr.Apply(context.Background(), users[0].ID, job.ID)
+	assert.True(t, errors.Is(err, shared.ErrJobFull) || errors.Is(err, shared.ErrAlreadyApplied))
+}
+
+func TestUserJobRepository_WithdrawRestore(t *testing.T) {
+	db := openTestDB(t)
+	ujr := repository.NewUserJobRepository(db)
+	ctx := context.Background()
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&applicant).Error)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "restore",
+		Quota:          1,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	application, err := ujr.Apply(ctx, applicant.ID, job.ID)
+	require.NoError(t, err)
+	application, err = ujr.UpdateStatus(ctx, application, model.ApplicationStatusScreening, poster.ID, "")
+	require.NoError(t, err)
+	_, err = ujr.Withdraw(ctx, application)
+	require.NoError(t, err)
+
+	deleted, _, err := ujr.FindDeleted(ctx, dto.PaginationQuery{Page: 1, Limit: 100})
+	require.NoError(t, err)
+	assert.Contains(t, applicationIds(deleted), application.ID)
+
+	restored, err := ujr.Restore(ctx, int(application.ID), poster.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.ApplicationStatusScreening, restored.Status)
+
+	require.NoError(t, db.First(&job, job.ID).Error)
+	assert.Equal(t, 0, job.Quota)
+
+	revisions, _, err := repository.NewJobRepository(db).FindRevisions(ctx, int(job.ID), dto.PaginationQuery{Page: 1, Limit: 10})
+	require.NoError(t, err)
+	if assert.Len(t, revisions, 3) {
+		assert.Equal(t, model.JobRevisionReasonApplied, revisions[0].Reason)
+		assert.Equal(t, applicant.ID, revisions[0].ChangedBy)
+		assert.Equal(t, model.JobRevisionReasonWithdrawn, revisions[1].Reason)
+		assert.Equal(t, applicant.ID, revisions[1].ChangedBy)
+		assert.Equal(t, 1, revisions[1].Quota)
+		assert.Equal(t, model.JobRevisionReasonApplicationRestored, revisions[2].Reason)
+		assert.Equal(t, poster.ID, revisions[2].ChangedBy)
+		assert.Equal(t, job.Version, revisions[2].Version)
+	}
+
+	_, err = ujr.Restore(ctx, int(application.ID), poster.ID)
+	assert.ErrorIs(t, err, shared.ErrApplicationNotFound)
+}
+
+func TestUserJobRepository_RestoreToClosedJob(t *testing.T) {
+	tests := []struct {
+		name  string
+		close func(t *testing.T, db *gorm.DB, job model.Jobs, poster model.Users)
+	}{
+		{
+			name: "closed",
+			close: func(t *testing.T, db *gorm.DB, job model.Jobs, poster model.Users) {
+				_, err := repository.NewJobRepository(db).UpdateStatus(context.Background(), job, model.JobStatusClosed, poster.ID, "close")
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "expired",
+			close: func(t *testing.T, db *gorm.DB, job model.Jobs, poster model.Users) {
+				require.NoError(t, db.Model(&job).Update("expiry_date", time.Now().Add(-time.Minute)).Error)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t)
+			ujr := repository.NewUserJobRepository(db)
+			ctx := context.Background()
+
+			poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+			require.NoError(t, db.Create(&poster).Error)
+			organization := createOrganization(t, db, poster)
+			applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+			require.NoError(t, db.Create(&applicant).Error)
+			job := model.Jobs{
+				OrganizationId: organization.ID,
+				JobPosterId:    poster.ID,
+				JobName:        tt.name,
+				Quota:          2,
+				Status:         model.JobStatusPublished,
+				ExpiryDate:     time.Now().Add(time.Hour),
+			}
+			require.NoError(t, db.Create(&job).Error)
+
+			application, err := ujr.Apply(ctx, applicant.ID, job.ID)
+			require.NoError(t, err)
+			_, err = ujr.Withdraw(ctx, application)
+			require.NoError(t, err)
+			require.NoError(t, db.First(&job, job.ID).Error)
+			tt.close(t, db, job, poster)
+
+			_, err = ujr.Restore(ctx, int(application.ID), poster.ID)
+
+			assert.ErrorIs(t, err, shared.ErrJobNotFound)
+			require.NoError(t, db.First(&job, job.ID).Error)
+			assert.Equal(t, 2, job.Quota)
+		})
+	}
+}
+
+func TestUserJobRepository_WithdrawReopensFilledJob(t *testing.T) {
+	db := openTestDB(t)
+	ujr := repository.NewUserJobRepository(db)
+	jr := repository.NewJobRepository(db)
+	ctx := context.Background()
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&applicant).Error)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "reopen",
+		Quota:          1,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	application, err := ujr.Apply(ctx, applicant.ID, job.ID)
+	require.NoError(t, err)
+	_, err = jr.CloseFilled(ctx)
+	require.NoError(t, err)
+	require.NoError(t, db.First(&job, job.ID).Error)
+	require.Equal(t, model.JobStatusClosed, job.Status)
+
+	_, err = ujr.Withdraw(ctx, application)
+	require.NoError(t, err)
+
+	require.NoError(t, db.First(&job, job.ID).Error)
+	assert.Equal(t, model.JobStatusPublished, job.Status)
+	assert.Equal(t, 1, job.Quota)
+
+	histories, err := jr.FindStatusHistory(ctx, int(job.ID))
+	require.NoError(t, err)
+	if assert.NotEmpty(t, histories) {
+		last := histories[len(histories)-1]
+		assert.Equal(t, model.JobStatusClosed, last.FromStatus)
+		assert.Equal(t, model.JobStatusPublished, last.ToStatus)
+		assert.Equal(t, model.JobReopenReasonQuotaReturned, last.Reason)
+		assert.Equal(t, applicant.ID, last.ChangedBy)
+	}
+}
+
+func TestUserJobRepository_WithdrawKeepsClosedJobClosed(t *testing.T) {
+	db := openTestDB(t)
+	ujr := repository.NewUserJobRepository(db)
+	jr := repository.NewJobRepository(db)
+	ctx := context.Background()
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&applicant).Error)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "closed",
+		Quota:          2,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+
+	application, err := ujr.Apply(ctx, applicant.ID, job.ID)
+	require.NoError(t, err)
+	require.NoError(t, db.First(&job, job.ID).Error)
+	_, err = jr.UpdateStatus(ctx, job, model.JobStatusClosed, poster.ID, "close")
+	require.NoError(t, err)
+
+	_, err = ujr.Withdraw(ctx, application)
+	require.NoError(t, err)
+
+	require.NoError(t, db.First(&job, job.ID).Error)
+	assert.Equal(t, model.JobStatusClosed, job.Status)
+	assert.Equal(t, 2, job.Quota)
+}
+
+func applicationIds(applications []model.UserJobs) []uint {
+	ids := []uint{}
+	for _, a := range applications {
+		ids = append(ids, a.ID)
+	}
+	return ids
+}
@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/model"
 	"github.com/adityatresnobudi/job-portal/shared"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type userRepository struct {
@@ -16,6 +19,16 @@ type userRepository struct {
 type UserRepository interface {
 	Create(ctx context.Context, user model.Users) (model.Users, error)
 	FindByEmail(ctx context.Context, email string) (model.Users, error)
+	FindById(ctx context.Context, id uint) (model.Users, error)
+	Update(ctx context.Context, user model.Users, fields []string) (model.Users, error)
+	ChangeEmail(ctx context.Context, tokenHash string) (model.Users, error)
+	MarkEmailVerified(ctx context.Context, id uint, email string) (model.Users, error)
+	UpdatePassword(ctx context.Context, id uint, passwordHash string) error
+	ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (model.Users, error)
+	Delete(ctx context.Context, id uint) error
+	FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Users, int64, error)
+	Restore(ctx context.Context, id uint) (model.Users, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
 
 func NewUserRepository(db *gorm.DB) UserRepository {
@@ -49,3 +62,301 @@ func (u *userRepository) FindByEmail(ctx context.Context, email string) (model.U
 
 	return user, nil
 }
+
+func (u *userRepository) FindById(ctx context.Context, id uint) (model.Users, error) {
+	user := model.Users{}
+
+	err := u.db.WithContext(ctx).
+		Model(&model.Users{}).
+		Where("id = ?", id).
+		First(&user).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Users{}, shared.ErrRecordNotFound
+		}
+		return model.Users{}, err
+	}
+
+	return user, nil
+}
+
+// Update writes only the given struct fields of user, so a field can be set
+// to its zero value.
+func (u *userRepository) Update(ctx context.Context, user model.Users, fields []string) (model.Users, error) {
+	res := u.db.WithContext(ctx).
+		Model(&model.Users{}).
+		Where("id = ?", user.ID).
+		Select(fields).
+		Updates(&user)
+	if res.Error != nil {
+		return model.Users{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.Users{}, shared.ErrRecordNotFound
+	}
+
+	return u.FindById(ctx, user.ID)
+}
+
+// ChangeEmail uses the email change token with the given hash and moves the
+// user to the email it was sent to, which the token proves verified. An
+// unknown, used or expired token is reported as ErrRecordNotFound.
+func (u *userRepository) ChangeEmail(ctx context.Context, tokenHash string) (model.Users, error) {
+	token := model.UserTokens{}
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		token, err = useToken(tx, model.UserTokenPurposeEmailChange, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		return updateUser(tx, token.UserId, map[string]interface{}{
+			"email":             token.Email,
+			"email_verified_at": time.Now(),
+		})
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Users{}, shared.ErrRecordNotFound
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return model.Users{}, shared.ErrEmailTaken
+		}
+		return model.Users{}, err
+	}
+
+	return u.FindById(ctx, token.UserId)
+}
+
+// MarkEmailVerified records that the user owns email. It is a no-op for an
+// already verified user and reports ErrRecordNotFound when email is no longer
+// the user's email.
+func (u *userRepository) MarkEmailVerified(ctx context.Context, id uint, email string) (model.Users, error) {
+	err := u.db.WithContext(ctx).
+		Model(&model.Users{}).
+		Where("id = ? AND email = ? AND email_verified_at IS NULL", id, email).
+		Update("email_verified_at", time.Now()).Error
+	if err != nil {
+		return model.Users{}, err
+	}
+
+	user, err := u.FindById(ctx, id)
+	if err != nil {
+		return model.Users{}, err
+	}
+	if user.Email != email {
+		return model.Users{}, shared.ErrRecordNotFound
+	}
+
+	return user, nil
+}
+
+// UpdatePassword stores the new hash and ends every session of the user, so
+// a stolen refresh token stops working once the password is changed.
+func (u *userRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := updateUser(tx, id, map[string]interface{}{"user_password": passwordHash}); err != nil {
+			return err
+		}
+		return revokeSessions(tx, id)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return shared.ErrRecordNotFound
+	}
+
+	return err
+}
+
+// ResetPassword uses the password reset token with the given hash to set a
+// new password and ends every session of the user. An unknown, used or
+// expired token is reported as ErrRecordNotFound.
+func (u *userRepository) ResetPassword(ctx context.Context, tokenHash string, passwordHash string) (model.Users, error) {
+	token := model.UserTokens{}
+
+	err := u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		token, err = useToken(tx, model.UserTokenPurposePasswordReset, tokenHash)
+		if err != nil {
+			return err
+		}
+
+		if err := updateUser(tx, token.UserId, map[string]interface{}{"user_password": passwordHash}); err != nil {
+			return err
+		}
+		return revokeSessions(tx, token.UserId)
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.Users{}, shared.ErrRecordNotFound
+		}
+		return model.Users{}, err
+	}
+
+	return u.FindById(ctx, token.UserId)
+}
+
+// useToken marks the unused, unexpired token with the given purpose and hash
+// as used. It returns gorm.ErrRecordNotFound when there is no such token.
+func useToken(tx *gorm.DB, purpose string, tokenHash string) (model.UserTokens, error) {
+	token := model.UserTokens{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("token_hash = ? AND purpose = ?", tokenHash, purpose).
+		Where("used_at IS NULL AND expires_at > NOW()").
+		First(&token).Error
+	if err != nil {
+		return model.UserTokens{}, err
+	}
+
+	if err := tx.Model(&token).Update("used_at", time.Now()).Error; err != nil {
+		return model.UserTokens{}, err
+	}
+
+	return token, nil
+}
+
+func updateUser(tx *gorm.DB, id uint, values map[string]interface{}) error {
+	res := tx.Model(&model.Users{}).Where("id = ?", id).Updates(values)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func revokeSessions(tx *gorm.DB, userId uint) error {
+	return tx.Model(&model.RefreshTokens{}).
+		Where("user_id = ? AND revoked_at IS NULL", userId).
+		Update("revoked_at", time.Now()).Error
+}
+
+// Delete soft deletes the user, takes them out of their organizations,
+// withdraws their open applications and ends every session they still have,
+// so a deleted account cannot keep acting through a refresh token nor hold
+// on to job quota. The jobs they posted belong to their
+// organizations and stay. Deleting the last owner of an organization is
+// refused with ErrLastOwner.
+func (u *userRepository) Delete(ctx context.Context, id uint) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&model.Users{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return shared.ErrRecordNotFound
+		}
+
+		if err := leaveOrganizations(tx, id); err != nil {
+			return err
+		}
+		if err := withdrawApplications(tx, id); err != nil {
+			return err
+		}
+
+		return revokeSessions(tx, id)
+	})
+}
+
+// leaveOrganizations takes a user out of every organization they belong to,
+// as long as each one keeps an owner.
+func leaveOrganizations(tx *gorm.DB, userId uint) error {
+	memberships := []model.OrganizationMembers{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ?", userId).
+		Order("organization_id ASC").
+		Find(&memberships).Error
+	if err != nil {
+		return err
+	}
+
+	for _, member := range memberships {
+		if member.Role == model.OrganizationRoleOwner {
+			if err := keepAnOwner(tx, member.OrganizationId); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Where("user_id = ?", userId).Delete(&model.OrganizationMembers{}).Error
+}
+
+// withdrawApplications withdraws the applications of a user that still hold
+// a slot of quota, as if the user had withdrawn each of them.
+func withdrawApplications(tx *gorm.DB, userId uint) error {
+	applications := []model.UserJobs{}
+
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("user_id = ? AND status IN ?", userId, model.OpenApplicationStatuses).
+		Order("id ASC").
+		Find(&applications).Error
+	if err != nil {
+		return err
+	}
+
+	for i := range applications {
+		if err := withdraw(tx, &applications[i], "account deleted"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (u *userRepository) FindDeleted(ctx context.Context, query dto.PaginationQuery) ([]model.Users, int64, error) {
+	users := []model.Users{}
+	var total int64
+
+	tx := u.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Users{}).
+		Where("deleted_at IS NOT NULL")
+
+	if err := tx.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	err := tx.Order("deleted_at DESC, id DESC").
+		Offset(query.Offset()).
+		Limit(query.Limit).
+		Find(&users).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// Restore undeletes the user. Their organization memberships are gone and
+// have to be granted again.
+func (u *userRepository) Restore(ctx context.Context, id uint) (model.Users, error) {
+	res := u.db.WithContext(ctx).
+		Unscoped().
+		Model(&model.Users{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if res.Error != nil {
+		if errors.Is(res.Error, gorm.ErrDuplicatedKey) {
+			return model.Users{}, shared.ErrEmailTaken
+		}
+		return model.Users{}, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return model.Users{}, shared.ErrRecordNotFound
+	}
+
+	return u.FindById(ctx, id)
+}
+
+// PurgeDeleted hard deletes users deleted before the cutoff. A user who still
+// has jobs, deleted or not, is kept until those jobs are purged.
+func (u *userRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	res := u.db.WithContext(ctx).
+		Unscoped().
+		Where("deleted_at < ?", before).
+		Where("NOT EXISTS (SELECT 1 FROM jobs WHERE jobs.job_poster_id = users.id)").
+		Delete(&model.Users{})
+
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,122 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRepository_Delete(t *testing.T) {
+	db := openTestDB(t)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	jr := repository.NewJobRepository(db)
+	ctx := context.Background()
+
+	owner := model.Users{Name: "owner", Email: fmt.Sprintf("owner-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&owner).Error)
+	recruiter := model.Users{Name: "recruiter", Email: fmt.Sprintf("recruiter-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&recruiter).Error)
+	organization := createOrganization(t, db, owner)
+	_, err := or.AddMember(ctx, model.OrganizationMembers{OrganizationId: organization.ID, UserId: recruiter.ID, Role: model.OrganizationRoleRecruiter})
+	require.NoError(t, err)
+	job, err := jr.Create(ctx, model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    recruiter.ID,
+		JobName:        "kept",
+		Quota:          1,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}, recruiter.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, ur.Delete(ctx, recruiter.ID))
+
+	_, err = or.FindMembership(ctx, organization.ID, recruiter.ID)
+	assert.ErrorIs(t, err, shared.ErrRecordNotFound)
+	kept, err := jr.FindAnyById(ctx, int(job.ID))
+	require.NoError(t, err)
+	assert.Equal(t, recruiter.ID, kept.JobPosterId)
+}
+
+func TestUserRepository_DeleteLastOwner(t *testing.T) {
+	db := openTestDB(t)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	ctx := context.Background()
+
+	owner := model.Users{Name: "owner", Email: fmt.Sprintf("owner-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&owner).Error)
+	organization := createOrganization(t, db, owner)
+
+	assert.ErrorIs(t, ur.Delete(ctx, owner.ID), shared.ErrLastOwner)
+
+	_, err := or.FindMembership(ctx, organization.ID, owner.ID)
+	assert.NoError(t, err)
+	_, err = ur.FindById(ctx, owner.ID)
+	assert.NoError(t, err)
+}
+
+func TestUserRepository_PurgeDeleted(t *testing.T) {
+	db := openTestDB(t)
+	ur := repository.NewUserRepository(db)
+	or := repository.NewOrganizationRepository(db)
+	ctx := context.Background()
+
+	creator := model.Users{Name: "creator", Email: fmt.Sprintf("creator-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&creator).Error)
+	successor := model.Users{Name: "successor", Email: fmt.Sprintf("successor-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&successor).Error)
+	organization := createOrganization(t, db, creator)
+	_, err := or.AddMember(ctx, model.OrganizationMembers{OrganizationId: organization.ID, UserId: successor.ID, Role: model.OrganizationRoleOwner})
+	require.NoError(t, err)
+	require.NoError(t, ur.Delete(ctx, creator.ID))
+
+	purged, err := ur.PurgeDeleted(ctx, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, purged, int64(1))
+
+	kept, err := or.FindById(ctx, organization.ID)
+	require.NoError(t, err)
+	assert.Nil(t, kept.CreatedBy)
+}
+
+func TestUserRepository_DeleteWithdrawsApplications(t *testing.T) {
+	db := openTestDB(t)
+	ur := repository.NewUserRepository(db)
+	ujr := repository.NewUserJobRepository(db)
+	ctx := context.Background()
+
+	poster := model.Users{Name: "poster", Email: fmt.Sprintf("poster-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&poster).Error)
+	organization := createOrganization(t, db, poster)
+	applicant := model.Users{Name: "applicant", Email: fmt.Sprintf("applicant-%d@test.local", time.Now().UnixNano())}
+	require.NoError(t, db.Create(&applicant).Error)
+	job := model.Jobs{
+		OrganizationId: organization.ID,
+		JobPosterId:    poster.ID,
+		JobName:        "quota",
+		Quota:          2,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     time.Now().Add(time.Hour),
+	}
+	require.NoError(t, db.Create(&job).Error)
+	application, err := ujr.Apply(ctx, applicant.ID, job.ID)
+	require.NoError(t, err)
+
+	require.NoError(t, ur.Delete(ctx, applicant.ID))
+
+	require.NoError(t, db.First(&job, job.ID).Error)
+	assert.Equal(t, 2, job.Quota)
+	withdrawn := model.UserJobs{}
+	require.NoError(t, db.Unscoped().First(&withdrawn, application.ID).Error)
+	assert.Equal(t, model.ApplicationStatusWithdrawn, withdrawn.Status)
+	assert.True(t, withdrawn.DeletedAt.Valid)
+}
@@ -9,60 +9,155 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
 	"github.com/adityatresnobudi/job-portal/db"
+	"github.com/adityatresnobudi/job-portal/event"
 	"github.com/adityatresnobudi/job-portal/handler"
+	"github.com/adityatresnobudi/job-portal/helper"
 	"github.com/adityatresnobudi/job-portal/logger"
 	"github.com/adityatresnobudi/job-portal/middleware"
+	"github.com/adityatresnobudi/job-portal/notify"
 	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/scheduler"
 	"github.com/adityatresnobudi/job-portal/usecase"
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 )
 
-func NewRouter(h *handler.Handler) *gin.Engine {
-	router := gin.Default()
+func NewRouter(h *handler.Handler, cfg config.Server, tokens *helper.JWT) *gin.Engine {
+	router := gin.New()
 	router.ContextWithFallback = true
 
 	router.Use(requestid.New())
+	router.Use(middleware.Language())
 	router.Use(middleware.Logger(logger.NewLogger()))
+	router.Use(middleware.Recovery())
 	router.Use(middleware.GlobalErrorMiddleware())
 
-	job := router.Group("/jobs", middleware.WithTimeout())
-	job.GET("", h.GetJobs)
-	job.POST("", middleware.Auth(), h.CreateNewJobs)
-	job.PUT("/:id/close", middleware.Auth(), h.CloseJobs)
-	job.PUT("/:id/update", middleware.Auth(), h.ChangeJobs)
+	authn := middleware.Auth(tokens, h.UserUsecase)
+	jobsWrite := middleware.RequirePermission(auth.PermissionJobsWrite)
+	applicantsManage := middleware.RequirePermission(auth.PermissionApplicantsManage)
+	applicationsApply := middleware.RequirePermission(auth.PermissionApplicationsApply)
+	applicationsRead := middleware.RequirePermission(auth.PermissionApplicationsRead)
+	adminManage := middleware.RequirePermission(auth.PermissionAdminManage)
+	verified := middleware.RequireVerifiedEmail()
+
+	router.GET("/.well-known/jwks.json", handler.JWKS(tokens))
 
-	user := router.Group("/auth", middleware.WithTimeout())
+	job := router.Group("/jobs", middleware.WithTimeout(cfg.RequestTimeout))
+	job.GET("", h.GetJobs)
+	job.GET("/search", h.SearchJobs)
+	job.GET("/:id", h.GetJobDetail)
+	job.POST("", authn, jobsWrite, verified, h.CreateNewJobs)
+	job.GET("/:id/status-history", authn, jobsWrite, h.GetJobStatusHistory)
+	job.GET("/:id/revisions", authn, h.GetJobRevisions)
+	job.GET("/:id/revisions/diff", authn, h.DiffJobRevisions)
+	job.PUT("/:id/publish", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionPublish))
+	job.PUT("/:id/pause", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionPause))
+	job.PUT("/:id/resume", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionResume))
+	job.PUT("/:id/close", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionClose))
+	job.PUT("/:id/reopen", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionReopen))
+	job.PUT("/:id/archive", authn, jobsWrite, h.ChangeJobStatus(usecase.JobActionArchive))
+	job.PATCH("/:id", authn, jobsWrite, h.PatchJob)
+	job.PUT("/:id/update", authn, jobsWrite, h.ChangeJobs)
+	job.DELETE("/:id", authn, jobsWrite, h.DeleteJob)
+	job.GET("/:id/applicants", authn, applicantsManage, h.GetJobApplicants)
+	job.PUT("/:id/applicants/:applicationId/status", authn, applicantsManage, h.ChangeApplicationStatus)
+	job.GET("/:id/applicants/:applicationId/history", authn, applicantsManage, h.GetApplicationHistory)
+
+	user := router.Group("/auth", middleware.WithTimeout(cfg.RequestTimeout))
 	user.POST("/register", h.CreateUser)
 	user.POST("/login", h.LoginUser)
-
-	userJob := router.Group("/users", middleware.WithTimeout())
-	userJob.POST("/apply", middleware.Auth(), h.ApplyJob)
+	user.POST("/refresh", h.RefreshToken)
+	user.POST("/logout", authn, h.Logout)
+	user.POST("/email/confirm", h.ConfirmEmail)
+	user.GET("/verify-email", h.VerifyEmail)
+	user.POST("/verify-email/resend", authn, h.ResendVerification)
+	user.POST("/password/forgot", h.ForgotPassword)
+	user.POST("/password/reset", h.ResetPassword)
+
+	userJob := router.Group("/users", middleware.WithTimeout(cfg.RequestTimeout))
+	userJob.GET("/me", authn, h.GetProfile)
+	userJob.PATCH("/me", authn, h.UpdateProfile)
+	userJob.PUT("/me/password", authn, h.ChangePassword)
+	userJob.GET("/:id/profile", authn, applicantsManage, h.GetPublicProfile)
+	userJob.POST("/apply", authn, applicationsApply, verified, h.ApplyJob)
+	userJob.GET("/applications", authn, applicationsRead, h.GetApplications)
+	userJob.GET("/applications/:id", authn, applicationsRead, h.GetApplication)
+	userJob.PUT("/applications/:id/withdraw", authn, applicationsApply, h.WithdrawApplication)
+
+	organization := router.Group("/organizations", middleware.WithTimeout(cfg.RequestTimeout), authn)
+	organization.POST("", jobsWrite, verified, h.CreateOrganization)
+	organization.GET("", h.GetOrganizations)
+	organization.GET("/:id", h.GetOrganization)
+	organization.POST("/:id/members", h.AddOrganizationMember)
+	organization.PUT("/:id/members/:userId", h.ChangeOrganizationMemberRole)
+	organization.DELETE("/:id/members/:userId", h.RemoveOrganizationMember)
+
+	admin := router.Group("/admin", middleware.WithTimeout(cfg.RequestTimeout), authn, adminManage)
+	admin.DELETE("/users/:id", h.DeleteUser)
+	admin.GET("/users/deleted", h.GetDeletedUsers)
+	admin.PUT("/users/:id/restore", h.RestoreUser)
+	admin.GET("/jobs/deleted", h.GetDeletedJobs)
+	admin.PUT("/jobs/:id/restore", h.RestoreJob)
+	admin.GET("/applications/deleted", h.GetDeletedApplications)
+	admin.PUT("/applications/:id/restore", h.RestoreApplication)
 
 	return router
 }
 
-func Serve() {
-	db, err := db.Connect()
+func Serve(cfg config.Config) {
+	log.Printf("starting with config %+v\n", cfg)
+
+	db, err := db.Connect(cfg.Database)
 	if err != nil {
-		log.Println(err)
+		log.Fatalf("connect database: %s\n", err)
+	}
+
+	tokens, err := helper.NewJWT(cfg.JWT)
+	if err != nil {
+		log.Fatalf("load jwt keys: %s\n", err)
 	}
 
 	jr := repository.NewJobRepository(db)
-	ju := usecase.NewJobUsecase(jr)
+	jsr := repository.NewJobSearcher(db)
+	or := repository.NewOrganizationRepository(db)
+	ju := usecase.NewJobUsecase(jr, jsr, or)
 
 	ur := repository.NewUserRepository(db)
-	uu := usecase.NewUserUsecase(ur)
+	tr := repository.NewTokenRepository(db)
+	notifier, err := notify.NewNotifier(cfg.Notify, logger.NewLogger())
+	if err != nil {
+		log.Fatalf("create notifier: %s\n", err)
+	}
+	uu := usecase.NewUserUsecase(ur, tr, tokens, notifier, cfg.Password, cfg.Email)
 
 	ujr := repository.NewUserJobRepository(db)
-	uju := usecase.NewUserJobUsecase(ujr)
+	uju := usecase.NewUserJobUsecase(ujr, jr, or)
+
+	ou := usecase.NewOrganizationUsecase(or, ur)
+
+	h := handler.NewHandler(ju, uu, uju, ou)
+	router := NewRouter(h, cfg.Server, tokens)
+
+	l := logger.NewLogger()
+	js := scheduler.NewJobScheduler(jr, event.NewLogPublisher(l), l, cfg.Scheduler.JobCloseInterval)
+	js.Start(context.Background())
 
-	h := handler.NewHandler(ju, uu, uju)
-	router := NewRouter(h)
+	kr := scheduler.NewKeyRotator(tokens, l, time.Minute)
+	kr.Start(context.Background())
+
+	ps := scheduler.NewPurgeScheduler([]scheduler.Purgeable{
+		{Name: "tokens", Purge: tr.PurgeExpired},
+		{Name: "applications", Purge: ujr.PurgeDeleted},
+		{Name: "jobs", Purge: jr.PurgeDeleted},
+		{Name: "users", Purge: ur.PurgeDeleted},
+	}, l, cfg.Scheduler.PurgeRetention, cfg.Scheduler.PurgeInterval)
+	ps.Start(context.Background())
 
 	srv := &http.Server{
-		Addr:    ":8080",
+		Addr:    cfg.Server.Addr,
 		Handler: router,
 	}
 
@@ -74,7 +169,7 @@ func Serve() {
 	}()
 
 	// Wait for interrupt signal to gracefully shutdown the server with
-	// a timeout of 5 seconds.
+	// a timeout of cfg.Server.ShutdownTimeout.
 	quit := make(chan os.Signal, 1)
 	// kill (no param) default send syscall.SIGTERM
 	// kill -2 is syscall.SIGINT
@@ -83,14 +178,23 @@ func Serve() {
 	<-quit
 	log.Println("Shutdown Server ...")
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	js.Stop()
+	log.Println("Job scheduler stopped")
+
+	kr.Stop()
+	log.Println("Key rotator stopped")
+
+	ps.Stop()
+	log.Println("Purge scheduler stopped")
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
 		log.Fatal("Server Shutdown:", err)
 	}
-	// catching ctx.Done(). timeout of 5 seconds.
+	// catching ctx.Done(). timeout of cfg.Server.ShutdownTimeout.
 	<-ctx.Done()
-	log.Println("timeout of 5 seconds.")
+	log.Printf("timeout of %s.\n", cfg.Server.ShutdownTimeout)
 
 	log.Println("Server exiting")
 }
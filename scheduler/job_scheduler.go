@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/event"
+	"github.com/adityatresnobudi/job-portal/logger"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+)
+
+type closeSweep struct {
+	reason string
+	close  func(ctx context.Context) ([]model.Jobs, error)
+}
+
+// JobScheduler periodically closes jobs that expired or ran out of quota.
+type JobScheduler struct {
+	jobRepo   repository.JobRepository
+	publisher event.Publisher
+	log       logger.Logger
+	interval  time.Duration
+
+	runner
+}
+
+func NewJobScheduler(jobRepo repository.JobRepository, publisher event.Publisher, log logger.Logger, interval time.Duration) *JobScheduler {
+	return &JobScheduler{
+		jobRepo:   jobRepo,
+		publisher: publisher,
+		log:       log,
+		interval:  interval,
+	}
+}
+
+// Start runs a sweep immediately and then once every interval until Stop is
+// called or ctx is cancelled.
+func (s *JobScheduler) Start(ctx context.Context) {
+	s.runEvery(ctx, s.interval, s.RunOnce)
+}
+
+func (s *JobScheduler) RunOnce(ctx context.Context) {
+	sweeps := []closeSweep{
+		{reason: model.JobCloseReasonExpired, close: s.jobRepo.CloseExpired},
+		{reason: model.JobCloseReasonFilled, close: s.jobRepo.CloseFilled},
+	}
+
+	for _, sweep := range sweeps {
+		if ctx.Err() != nil {
+			return
+		}
+
+		jobs, err := sweep.close(ctx)
+		if err != nil {
+			s.log.Errorf("scheduler: closing %s jobs: %v", sweep.reason, err)
+			continue
+		}
+
+		for _, job := range jobs {
+			err := s.publisher.Publish(ctx, event.Event{
+				Type: event.JobClosed,
+				Data: map[string]interface{}{
+					"job_id": job.ID,
+					"reason": sweep.reason,
+				},
+				OccurredAt: time.Now(),
+			})
+			if err != nil {
+				s.log.Errorf("scheduler: publishing %s for job %d: %v", event.JobClosed, job.ID, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/event"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/scheduler"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestJobScheduler_RunOnce(t *testing.T) {
+	t.Run("should publish an event for every closed job", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockPublisher := new(mocks.Publisher)
+		mockLogger := new(mocks.Logger)
+		s := scheduler.NewJobScheduler(mockJobRepo, mockPublisher, mockLogger, time.Minute)
+		mockJobRepo.On("CloseExpired", mock.Anything).Return([]model.Jobs{{ID: 1}}, nil)
+		mockJobRepo.On("CloseFilled", mock.Anything).Return([]model.Jobs{{ID: 2}}, nil)
+		mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e event.Event) bool {
+			return e.Data["job_id"] == uint(1) && e.Data["reason"] == model.JobCloseReasonExpired
+		})).Return(nil).Once()
+		mockPublisher.On("Publish", mock.Anything, mock.MatchedBy(func(e event.Event) bool {
+			return e.Data["job_id"] == uint(2) && e.Data["reason"] == model.JobCloseReasonFilled
+		})).Return(nil).Once()
+
+		s.RunOnce(context.Background())
+
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("should keep sweeping when one sweep fails", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockPublisher := new(mocks.Publisher)
+		mockLogger := new(mocks.Logger)
+		s := scheduler.NewJobScheduler(mockJobRepo, mockPublisher, mockLogger, time.Minute)
+		mockJobRepo.On("CloseExpired", mock.Anything).Return(nil, errors.New("connection refused"))
+		mockJobRepo.On("CloseFilled", mock.Anything).Return([]model.Jobs{}, nil)
+		mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+
+		s.RunOnce(context.Background())
+
+		mockJobRepo.AssertCalled(t, "CloseFilled", mock.Anything)
+		mockPublisher.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobScheduler_Stop(t *testing.T) {
+	mockJobRepo := new(mocks.JobRepository)
+	mockPublisher := new(mocks.Publisher)
+	mockLogger := new(mocks.Logger)
+	s := scheduler.NewJobScheduler(mockJobRepo, mockPublisher, mockLogger, time.Hour)
+	mockJobRepo.On("CloseExpired", mock.Anything).Return([]model.Jobs{}, nil)
+	mockJobRepo.On("CloseFilled", mock.Anything).Return([]model.Jobs{}, nil)
+
+	s.Start(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Stop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop")
+	}
+}
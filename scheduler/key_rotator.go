@@ -0,0 +1,52 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/logger"
+)
+
+// KeyRotation is the part of helper.JWT the rotator drives.
+type KeyRotation interface {
+	RotateIfDue(now time.Time) (string, error)
+}
+
+// KeyRotator periodically checks whether the JWT signing key is due for
+// rotation. The check interval only bounds how late a rotation can happen;
+// the rotation period itself comes from the JWT config.
+type KeyRotator struct {
+	keys     KeyRotation
+	log      logger.Logger
+	interval time.Duration
+
+	runner
+}
+
+func NewKeyRotator(keys KeyRotation, log logger.Logger, interval time.Duration) *KeyRotator {
+	return &KeyRotator{
+		keys:     keys,
+		log:      log,
+		interval: interval,
+	}
+}
+
+// Start checks immediately, so a key that became due while the server was
+// down is replaced on boot, and then once every interval until Stop is called
+// or ctx is cancelled.
+func (r *KeyRotator) Start(ctx context.Context) {
+	r.runEvery(ctx, r.interval, func(context.Context) {
+		r.RunOnce(time.Now())
+	})
+}
+
+func (r *KeyRotator) RunOnce(now time.Time) {
+	kid, err := r.keys.RotateIfDue(now)
+	if err != nil {
+		r.log.Errorf("scheduler: rotating jwt signing key: %v", err)
+		return
+	}
+	if kid != "" {
+		r.log.Infof("scheduler: rotated jwt signing key, new kid %s", kid)
+	}
+}
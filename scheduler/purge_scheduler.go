@@ -0,0 +1,63 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/logger"
+)
+
+// Purgeable hard deletes the records of one kind that were soft deleted, or
+// expired, before a cutoff.
+type Purgeable struct {
+	Name  string
+	Purge func(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PurgeScheduler periodically hard deletes soft deleted records once they are
+// older than the retention period. Purgeables run in order, so records that
+// reference others must come first.
+type PurgeScheduler struct {
+	purgeables []Purgeable
+	log        logger.Logger
+	retention  time.Duration
+	interval   time.Duration
+
+	runner
+}
+
+func NewPurgeScheduler(purgeables []Purgeable, log logger.Logger, retention time.Duration, interval time.Duration) *PurgeScheduler {
+	return &PurgeScheduler{
+		purgeables: purgeables,
+		log:        log,
+		retention:  retention,
+		interval:   interval,
+	}
+}
+
+// Start purges immediately and then once every interval until Stop is called
+// or ctx is cancelled.
+func (s *PurgeScheduler) Start(ctx context.Context) {
+	s.runEvery(ctx, s.interval, func(ctx context.Context) {
+		s.RunOnce(ctx, time.Now())
+	})
+}
+
+func (s *PurgeScheduler) RunOnce(ctx context.Context, now time.Time) {
+	before := now.Add(-s.retention)
+
+	for _, p := range s.purgeables {
+		if ctx.Err() != nil {
+			return
+		}
+
+		purged, err := p.Purge(ctx, before)
+		if err != nil {
+			s.log.Errorf("scheduler: purging deleted %s: %v", p.Name, err)
+			continue
+		}
+		if purged > 0 {
+			s.log.Infof("scheduler: purged %d deleted %s", purged, p.Name)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestPurgeScheduler_RunOnce(t *testing.T) {
+	t.Run("should purge in order with the retention cutoff", func(t *testing.T) {
+		now := time.Date(2023, 6, 30, 12, 0, 0, 0, time.UTC)
+		order := []string{}
+		purge := func(name string) func(context.Context, time.Time) (int64, error) {
+			return func(_ context.Context, before time.Time) (int64, error) {
+				assert.Equal(t, now.Add(-24*time.Hour), before)
+				order = append(order, name)
+				return 0, nil
+			}
+		}
+		s := scheduler.NewPurgeScheduler([]scheduler.Purgeable{
+			{Name: "applications", Purge: purge("applications")},
+			{Name: "jobs", Purge: purge("jobs")},
+		}, new(mocks.Logger), 24*time.Hour, time.Hour)
+
+		s.RunOnce(context.Background(), now)
+
+		assert.Equal(t, []string{"applications", "jobs"}, order)
+	})
+
+	t.Run("should keep purging when one kind fails", func(t *testing.T) {
+		mockLogger := new(mocks.Logger)
+		purgedJobs := false
+		s := scheduler.NewPurgeScheduler([]scheduler.Purgeable{
+			{Name: "applications", Purge: func(context.Context, time.Time) (int64, error) {
+				return 0, errors.New("connection refused")
+			}},
+			{Name: "jobs", Purge: func(context.Context, time.Time) (int64, error) {
+				purgedJobs = true
+				return 2, nil
+			}},
+		}, mockLogger, 24*time.Hour, time.Hour)
+		mockLogger.On("Errorf", mock.Anything, mock.Anything, mock.Anything).Return()
+		mockLogger.On("Infof", mock.Anything, int64(2), "jobs").Return()
+
+		s.RunOnce(context.Background(), time.Now())
+
+		assert.True(t, purgedJobs)
+		mockLogger.AssertExpectations(t)
+	})
+}
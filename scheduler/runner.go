@@ -0,0 +1,46 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// runner is the loop every scheduler runs in: the work is done once right
+// away and then once every interval until Stop is called or the context is
+// cancelled. Schedulers embed it.
+type runner struct {
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func (r *runner) runEvery(ctx context.Context, interval time.Duration, fn func(ctx context.Context)) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			fn(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop cancels the loop, if it runs, and waits for the work in progress to
+// return.
+func (r *runner) Stop() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
@@ -3,52 +3,187 @@ package shared
 import (
 	"fmt"
 	"net/http"
+	"strings"
 )
 
 var (
-	ErrGettingJobs        = NewCustomError(http.StatusInternalServerError, "error getting all jobs")
-	ErrCreatingJobs       = NewCustomError(http.StatusInternalServerError, "error creating jobs")
-	ErrInvalidRequestBody = NewCustomError(http.StatusBadRequest, "invalid request body")
-	ErrFindingJobs        = NewCustomError(http.StatusInternalServerError, "error finding jobs")
-	ErrIdNotFound         = NewCustomError(http.StatusBadRequest, "id not found")
-	ErrRecordNotFound     = NewCustomError(http.StatusBadRequest, "record not found")
-	ErrCreateUsers        = NewCustomError(http.StatusInternalServerError, "error creating users")
-	ErrInvalidToken       = NewCustomError(http.StatusUnauthorized, "error invalid token")
-	ErrInvalidAuthHeader  = NewCustomError(http.StatusUnauthorized, "error invalid auth header")
-	ErrJobNotFound        = NewCustomError(http.StatusBadRequest, "error job not found")
-	ErrUnauthorized       = NewCustomError(http.StatusBadRequest, "error unauthorized")
-	ErrMinusQuota         = NewCustomError(http.StatusBadRequest, "quota is less than zero")
-	ErrJobTransaction     = NewCustomError(http.StatusInternalServerError, "error job transaction")
-	ErrCreateApplyJob     = NewCustomError(http.StatusInternalServerError, "error creating apply job")
-	ErrGettingUserJob     = NewCustomError(http.StatusInternalServerError, "error getting user job")
-	ErrAlreadyApplied     = NewCustomError(http.StatusBadRequest, "already applied to the job")
-	ErrUserDoesntExist    = NewCustomError(http.StatusBadRequest, "invalid email or password")
-	ErrFailedLogin        = NewCustomError(http.StatusInternalServerError, "error failed login")
-	ErrInvalidPassword    = NewCustomError(http.StatusBadRequest, "invalid email or password")
+	ErrGettingJobs                  = NewCustomError(http.StatusInternalServerError, "getting_jobs", "error getting all jobs")
+	ErrCreatingJobs                 = NewCustomError(http.StatusInternalServerError, "creating_jobs", "error creating jobs")
+	ErrInvalidRequestBody           = NewCustomError(http.StatusBadRequest, "invalid_request_body", "invalid request body")
+	ErrInvalidQueryParam            = NewCustomError(http.StatusBadRequest, "invalid_query_param", "invalid query parameter")
+	ErrFindingJobs                  = NewCustomError(http.StatusInternalServerError, "finding_jobs", "error finding jobs")
+	ErrSearchingJobs                = NewCustomError(http.StatusInternalServerError, "searching_jobs", "error searching jobs")
+	ErrInvalidSearchQuery           = NewCustomError(http.StatusBadRequest, "invalid_search_query", "search query has no searchable terms")
+	ErrIdNotFound                   = NewCustomError(http.StatusBadRequest, "id_not_found", "id not found")
+	ErrRecordNotFound               = NewCustomError(http.StatusNotFound, "record_not_found", "record not found")
+	ErrCreateUsers                  = NewCustomError(http.StatusInternalServerError, "create_users", "error creating users")
+	ErrInvalidToken                 = NewCustomError(http.StatusUnauthorized, "invalid_token", "error invalid token")
+	ErrTokenRevoked                 = NewCustomError(http.StatusUnauthorized, "token_revoked", "token has been revoked")
+	ErrVerifyingToken               = NewCustomError(http.StatusInternalServerError, "verifying_token", "error verifying token")
+	ErrInvalidRefreshToken          = NewCustomError(http.StatusUnauthorized, "invalid_refresh_token", "invalid refresh token")
+	ErrRefreshTokenReused           = NewCustomError(http.StatusUnauthorized, "refresh_token_reused", "refresh token was already used, session revoked")
+	ErrRefreshingToken              = NewCustomError(http.StatusInternalServerError, "refreshing_token", "error refreshing token")
+	ErrFailedLogout                 = NewCustomError(http.StatusInternalServerError, "failed_logout", "error failed logout")
+	ErrInvalidAuthHeader            = NewCustomError(http.StatusUnauthorized, "invalid_auth_header", "error invalid auth header")
+	ErrJobNotFound                  = NewCustomError(http.StatusNotFound, "job_not_found", "error job not found")
+	ErrForbidden                    = NewCustomError(http.StatusForbidden, "forbidden", "error forbidden")
+	ErrUnauthorized                 = NewCustomError(http.StatusUnauthorized, "unauthorized", "error unauthorized")
+	ErrUnknownJobAction             = NewCustomError(http.StatusBadRequest, "unknown_job_action", "unknown job action")
+	ErrIllegalTransition            = NewCustomError(http.StatusConflict, "illegal_transition", "job status does not allow this action")
+	ErrJobStatusConflict            = NewCustomError(http.StatusConflict, "job_status_conflict", "job status was changed by another request")
+	ErrUpdatingJobStatus            = NewCustomError(http.StatusInternalServerError, "updating_job_status", "error updating job status")
+	ErrMinusQuota                   = NewCustomError(http.StatusBadRequest, "minus_quota", "quota is less than zero")
+	ErrJobTransaction               = NewCustomError(http.StatusInternalServerError, "job_transaction", "error job transaction")
+	ErrCreateApplyJob               = NewCustomError(http.StatusInternalServerError, "create_apply_job", "error creating apply job")
+	ErrGettingUserJob               = NewCustomError(http.StatusInternalServerError, "getting_user_job", "error getting user job")
+	ErrAlreadyApplied               = NewCustomError(http.StatusConflict, "already_applied", "already applied to the job")
+	ErrApplicationNotFound          = NewCustomError(http.StatusNotFound, "application_not_found", "application not found")
+	ErrGettingApplications          = NewCustomError(http.StatusInternalServerError, "getting_applications", "error getting applications")
+	ErrIllegalApplicationTransition = NewCustomError(http.StatusConflict, "illegal_application_transition", "application status does not allow this change")
+	ErrUpdatingApplicationStatus    = NewCustomError(http.StatusInternalServerError, "updating_application_status", "error updating application status")
+	ErrCannotWithdraw               = NewCustomError(http.StatusConflict, "cannot_withdraw", "application can no longer be withdrawn")
+	ErrApplicationStatusConflict    = NewCustomError(http.StatusConflict, "application_status_conflict", "application status was changed by another request")
+	ErrWithdrawingApplication       = NewCustomError(http.StatusInternalServerError, "withdrawing_application", "error withdrawing application")
+	ErrJobFull                      = NewCustomError(http.StatusConflict, "job_full", "job has no remaining quota")
+	ErrUserNotFound                 = NewCustomError(http.StatusNotFound, "user_not_found", "user not found")
+	ErrDeletingUser                 = NewCustomError(http.StatusInternalServerError, "deleting_user", "error deleting user")
+	ErrRestoringUser                = NewCustomError(http.StatusInternalServerError, "restoring_user", "error restoring user")
+	ErrEmailTaken                   = NewCustomError(http.StatusConflict, "email_taken", "email is already used by another account")
+	ErrUpdatingJob                  = NewCustomError(http.StatusInternalServerError, "updating_job", "error updating job")
+	ErrJobVersionMismatch           = NewCustomError(http.StatusPreconditionFailed, "job_version_mismatch", "job was changed by another request, fetch it again and retry")
+	ErrJobPreconditionRequired      = NewCustomError(http.StatusPreconditionRequired, "job_precondition_required", "send the ETag of the job in If-Match to change it")
+	ErrUnsupportedMediaType         = NewCustomError(http.StatusUnsupportedMediaType, "unsupported_media_type", "unsupported content type")
+	ErrJobRevisionNotFound          = NewCustomError(http.StatusNotFound, "job_revision_not_found", "job revision not found")
+	ErrGettingJobRevisions          = NewCustomError(http.StatusInternalServerError, "getting_job_revisions", "error getting job revisions")
+	ErrDeletingJob                  = NewCustomError(http.StatusInternalServerError, "deleting_job", "error deleting job")
+	ErrRestoringJob                 = NewCustomError(http.StatusInternalServerError, "restoring_job", "error restoring job")
+	ErrJobOrganizationMissing       = NewCustomError(http.StatusConflict, "job_organization_missing", "the organization of the job no longer exists")
+	ErrRestoringApplication         = NewCustomError(http.StatusInternalServerError, "restoring_application", "error restoring application")
+	ErrGettingDeletedRecords        = NewCustomError(http.StatusInternalServerError, "getting_deleted_records", "error getting deleted records")
+	ErrGettingProfile               = NewCustomError(http.StatusInternalServerError, "getting_profile", "error getting profile")
+	ErrUpdatingProfile              = NewCustomError(http.StatusInternalServerError, "updating_profile", "error updating profile")
+	ErrInvalidEmailToken            = NewCustomError(http.StatusBadRequest, "invalid_email_token", "invalid or expired email confirmation token")
+	ErrChangingEmail                = NewCustomError(http.StatusInternalServerError, "changing_email", "error changing email")
+	ErrWrongPassword                = NewCustomError(http.StatusBadRequest, "wrong_password", "current password is incorrect")
+	ErrChangingPassword             = NewCustomError(http.StatusInternalServerError, "changing_password", "error changing password")
+	ErrRequestingPasswordReset      = NewCustomError(http.StatusInternalServerError, "requesting_password_reset", "error requesting password reset")
+	ErrInvalidPasswordResetToken    = NewCustomError(http.StatusBadRequest, "invalid_password_reset_token", "invalid or expired password reset token")
+	ErrResettingPassword            = NewCustomError(http.StatusInternalServerError, "resetting_password", "error resetting password")
+	ErrEmailNotVerified             = NewCustomError(http.StatusForbidden, "email_not_verified", "verify your email first")
+	ErrEmailAlreadyVerified         = NewCustomError(http.StatusConflict, "email_already_verified", "email is already verified")
+	ErrInvalidVerificationLink      = NewCustomError(http.StatusBadRequest, "invalid_verification_link", "invalid email verification link")
+	ErrVerificationLinkExpired      = NewCustomError(http.StatusBadRequest, "verification_link_expired", "email verification link has expired, request a new one")
+	ErrVerifyingEmail               = NewCustomError(http.StatusInternalServerError, "verifying_email", "error verifying email")
+	ErrSendingVerification          = NewCustomError(http.StatusInternalServerError, "sending_verification", "error sending verification email")
+	ErrOrganizationNotFound         = NewCustomError(http.StatusNotFound, "organization_not_found", "organization not found")
+	ErrCreatingOrganization         = NewCustomError(http.StatusInternalServerError, "creating_organization", "error creating organization")
+	ErrGettingOrganizations         = NewCustomError(http.StatusInternalServerError, "getting_organizations", "error getting organizations")
+	ErrMemberNotFound               = NewCustomError(http.StatusNotFound, "member_not_found", "organization member not found")
+	ErrAlreadyMember                = NewCustomError(http.StatusConflict, "already_member", "user is already a member of the organization")
+	ErrLastOwner                    = NewCustomError(http.StatusConflict, "last_owner", "an organization needs at least one owner")
+	ErrPosterNotMember              = NewCustomError(http.StatusBadRequest, "poster_not_member", "job poster is not a member of the organization")
+	ErrUpdatingMembers              = NewCustomError(http.StatusInternalServerError, "updating_members", "error updating organization members")
+	ErrCheckingMembership           = NewCustomError(http.StatusInternalServerError, "checking_membership", "error checking organization membership")
+	ErrInvalidCredentials           = NewCustomError(http.StatusUnauthorized, "invalid_credentials", "invalid email or password")
+	ErrFailedLogin                  = NewCustomError(http.StatusInternalServerError, "failed_login", "error failed login")
 )
 
+// ProblemContentType is the media type of RFC 7807 problem details.
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix namespaces error codes into the problem type URI.
+const problemTypePrefix = "urn:job-portal:problem:"
+
+// ErrInternal is reported in place of any error that is not a CustomError,
+// so internal details never reach the client.
+var ErrInternal = NewCustomError(http.StatusInternalServerError, "internal_error", "an unexpected error occurred")
+
+// CustomError is an error the API reports to clients. Code is stable for
+// clients to switch on; Message is for humans and may change.
 type CustomError struct {
-	StatusCode int    `json:"code"`
+	StatusCode int    `json:"status"`
+	Code       string `json:"code"`
 	Message    string `json:"message"`
 }
 
-type ErrorDTO struct {
-	Message string `json:"message"`
+// Problem is an RFC 7807 problem details body. Code and Errors are
+// extension members.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
 }
 
-func NewCustomError(statuscode int, message string) *CustomError {
+func NewCustomError(statuscode int, code string, message string) *CustomError {
 	return &CustomError{
 		StatusCode: statuscode,
+		Code:       code,
 		Message:    message,
 	}
 }
 
 func (ce *CustomError) Error() string {
-	return fmt.Sprintf("Error %d: %s", ce.StatusCode, ce.Message)
+	return fmt.Sprintf("Error %d %s: %s", ce.StatusCode, ce.Code, ce.Message)
+}
+
+// ToProblem describes the error as problem details; instance identifies the
+// request it occurred in.
+func (ce *CustomError) ToProblem(instance string) Problem {
+	return newProblem(ce.StatusCode, ce.Code, ce.Message, instance)
+}
+
+// FieldError describes why one field of a request was rejected. Code is
+// stable for clients to switch on; Message is for humans. Args fill the
+// message template after the field name when it is translated.
+type FieldError struct {
+	Field   string        `json:"field"`
+	Code    string        `json:"code"`
+	Message string        `json:"message"`
+	Args    []interface{} `json:"-"`
+}
+
+// ValidationError rejects a request and lists every offending field.
+type ValidationError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Fields     []FieldError
+}
+
+func NewValidationError(fields ...FieldError) *ValidationError {
+	return &ValidationError{
+		StatusCode: http.StatusBadRequest,
+		Code:       "validation_failed",
+		Message:    "request failed validation",
+		Fields:     fields,
+	}
+}
+
+func (ve *ValidationError) Error() string {
+	messages := make([]string, 0, len(ve.Fields))
+	for _, f := range ve.Fields {
+		messages = append(messages, f.Message)
+	}
+	return fmt.Sprintf("Error %d %s: %s: %s", ve.StatusCode, ve.Code, ve.Message, strings.Join(messages, "; "))
+}
+
+func (ve *ValidationError) ToProblem(instance string) Problem {
+	problem := newProblem(ve.StatusCode, ve.Code, ve.Message, instance)
+	problem.Errors = ve.Fields
+	return problem
 }
 
-func (ce *CustomError) ToErrorDTO() *ErrorDTO {
-	return &ErrorDTO{
-		Message: ce.Message,
+func newProblem(status int, code, detail, instance string) Problem {
+	return Problem{
+		Type:     problemTypePrefix + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
 	}
 }
@@ -2,39 +2,100 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/adityatresnobudi/job-portal/auth"
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/model"
 	"github.com/adityatresnobudi/job-portal/repository"
 	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"gorm.io/gorm"
 )
 
+const (
+	JobActionPublish = "publish"
+	JobActionPause   = "pause"
+	JobActionResume  = "resume"
+	JobActionClose   = "close"
+	JobActionReopen  = "reopen"
+	JobActionArchive = "archive"
+)
+
+type jobTransition struct {
+	from []string
+	to   string
+}
+
+// jobTransitions is the job lifecycle: every action lists the statuses it
+// may be taken from and the status it moves the job to.
+var jobTransitions = map[string]jobTransition{
+	JobActionPublish: {from: []string{model.JobStatusDraft}, to: model.JobStatusPublished},
+	JobActionPause:   {from: []string{model.JobStatusPublished}, to: model.JobStatusPaused},
+	JobActionResume:  {from: []string{model.JobStatusPaused}, to: model.JobStatusPublished},
+	JobActionClose:   {from: []string{model.JobStatusPublished, model.JobStatusPaused}, to: model.JobStatusClosed},
+	JobActionReopen:  {from: []string{model.JobStatusClosed}, to: model.JobStatusPublished},
+	JobActionArchive: {from: []string{model.JobStatusDraft, model.JobStatusClosed}, to: model.JobStatusArchived},
+}
+
+func (t jobTransition) allows(status string) bool {
+	for _, from := range t.from {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
+
+// jobEditable tells whether the posting of a job in status may be edited.
+// Closed and archived jobs are left as they ended.
+func jobEditable(status string) bool {
+	switch status {
+	case model.JobStatusDraft, model.JobStatusPublished, model.JobStatusPaused:
+		return true
+	}
+	return false
+}
+
 type jobUsecase struct {
-	jobRepo repository.JobRepository
+	jobRepo     repository.JobRepository
+	jobSearcher repository.JobSearcher
+	orgRepo     repository.OrganizationRepository
 }
 
 type JobUsecase interface {
-	GetAvailableJobs(ctx context.Context, name string) ([]dto.JobsDTO, error)
+	GetAvailableJobs(ctx context.Context, query dto.JobsQuery) ([]dto.JobsDTO, dto.PaginationResponse, error)
+	SearchJobs(ctx context.Context, query dto.JobSearchQuery) ([]dto.JobSearchResult, dto.PaginationResponse, error)
 	GetJobsByID(ctx context.Context, jobId int) (dto.CloseJobsResponse, error)
-	CreateJobs(ctx context.Context, newJob dto.JobsPayload, jobPosterId uint) (dto.JobsResponse, error)
-	CloseJob(ctx context.Context, closeJob dto.CloseJobsResponse, jobPosterId uint) (dto.CloseJobsResponse, error)
-	UpdateQuota(ctx context.Context, updateJob dto.CloseJobsResponse, quota int, jobPosterId uint) (dto.CloseJobsResponse, error)
-	UpdateExpDate(ctx context.Context, updateJob dto.CloseJobsResponse, expDate string, jobPosterId uint) (dto.CloseJobsResponse, error)
+	GetJobDetail(ctx context.Context, jobId int) (dto.JobDetailResponse, error)
+	CreateJobs(ctx context.Context, newJob dto.JobsPayload, principal auth.Principal) (dto.JobsResponse, error)
+	ChangeJobStatus(ctx context.Context, jobId int, action string, principal auth.Principal) (dto.CloseJobsResponse, error)
+	GetJobStatusHistory(ctx context.Context, jobId int, principal auth.Principal) ([]dto.JobStatusHistoryDTO, error)
+	PatchJob(ctx context.Context, jobId int, patch dto.JobPatch, versions []int, principal auth.Principal) (dto.CloseJobsResponse, error)
+	GetJobRevisions(ctx context.Context, jobId int, query dto.PaginationQuery, principal auth.Principal) ([]dto.JobRevisionDTO, dto.PaginationResponse, error)
+	DiffJobRevisions(ctx context.Context, jobId int, query dto.JobRevisionDiffQuery, principal auth.Principal) (dto.JobRevisionDiffDTO, error)
+	DeleteJob(ctx context.Context, jobId int, principal auth.Principal) error
+	GetDeletedJobs(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedJobDTO, dto.PaginationResponse, error)
+	RestoreJob(ctx context.Context, jobId int) (dto.DeletedJobDTO, error)
 }
 
-func NewJobUsecase(jobRepo repository.JobRepository) JobUsecase {
+func NewJobUsecase(jobRepo repository.JobRepository, jobSearcher repository.JobSearcher, orgRepo repository.OrganizationRepository) JobUsecase {
 	return &jobUsecase{
-		jobRepo: jobRepo,
+		jobRepo:     jobRepo,
+		jobSearcher: jobSearcher,
+		orgRepo:     orgRepo,
 	}
 }
 
-func (ju *jobUsecase) GetAvailableJobs(ctx context.Context, name string) ([]dto.JobsDTO, error) {
+func (ju *jobUsecase) GetAvailableJobs(ctx context.Context, query dto.JobsQuery) ([]dto.JobsDTO, dto.PaginationResponse, error) {
 	jobs := []dto.JobsDTO{}
 	job := dto.JobsDTO{}
-	jobList, err := ju.jobRepo.FindAll(ctx, name)
+	query.Normalize()
+
+	jobList, total, err := ju.jobRepo.FindAll(ctx, query)
 	if err != nil {
-		return nil, shared.ErrGettingJobs
+		return nil, dto.PaginationResponse{}, shared.ErrGettingJobs
 	}
 
 	for _, j := range jobList {
@@ -46,13 +107,48 @@ func (ju *jobUsecase) GetAvailableJobs(ctx context.Context, name string) ([]dto.
 		jobs = append(jobs, job)
 	}
 
-	return jobs, nil
+	return jobs, dto.NewPaginationResponse(query.PaginationQuery, total), nil
+}
+
+func (ju *jobUsecase) SearchJobs(ctx context.Context, query dto.JobSearchQuery) ([]dto.JobSearchResult, dto.PaginationResponse, error) {
+	results := []dto.JobSearchResult{}
+	query.Normalize()
+
+	hits, total, err := ju.jobSearcher.Search(ctx, query)
+	if err != nil {
+		if errors.Is(err, shared.ErrInvalidSearchQuery) {
+			return nil, dto.PaginationResponse{}, shared.ErrInvalidSearchQuery
+		}
+		return nil, dto.PaginationResponse{}, shared.ErrSearchingJobs
+	}
+
+	for _, hit := range hits {
+		results = append(results, dto.JobSearchResult{
+			JobsDTO: dto.JobsDTO{
+				ID:          hit.ID,
+				JobPosterId: hit.JobPosterId,
+				JobName:     hit.JobName,
+				JobDesc:     hit.JobDesc,
+				Quota:       hit.Quota,
+			},
+			Rank: hit.Rank,
+			Highlights: dto.JobsHighlightDTO{
+				JobName: hit.NameHighlight,
+				JobDesc: hit.DescSnippet,
+			},
+		})
+	}
+
+	return results, dto.NewPaginationResponse(query.PaginationQuery, total), nil
 }
 
 func (ju *jobUsecase) GetJobsByID(ctx context.Context, jobId int) (dto.CloseJobsResponse, error) {
 	closeJob := dto.CloseJobsResponse{}
 	cj, err := ju.jobRepo.FindById(ctx, jobId)
 	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.CloseJobsResponse{}, shared.ErrJobNotFound
+		}
 		return dto.CloseJobsResponse{}, shared.ErrGettingJobs
 	}
 	if cj.ID == 0 {
@@ -60,153 +156,458 @@ func (ju *jobUsecase) GetJobsByID(ctx context.Context, jobId int) (dto.CloseJobs
 	}
 
 	closeJob.ID = cj.ID
+	closeJob.OrganizationId = cj.OrganizationId
 	closeJob.JobPosterId = cj.JobPosterId
 	closeJob.JobName = cj.JobName
+	closeJob.JobDesc = cj.JobDesc
 	closeJob.Quota = cj.Quota
-	closeJob.IsOpen = cj.IsOpen
+	closeJob.Status = cj.Status
 	closeJob.ExpiryDate = TimeToStrConv(cj.ExpiryDate)
+	closeJob.Version = cj.Version
 
 	return closeJob, nil
 }
 
-func (ju *jobUsecase) CreateJobs(ctx context.Context, newJob dto.JobsPayload, jobPosterId uint) (dto.JobsResponse, error) {
-	if newJob.JobPosterId != jobPosterId {
-		return dto.JobsResponse{}, shared.ErrUnauthorized
+func (ju *jobUsecase) GetJobDetail(ctx context.Context, jobId int) (dto.JobDetailResponse, error) {
+	job, err := ju.jobRepo.FindByIdWithPoster(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.JobDetailResponse{}, shared.ErrJobNotFound
+		}
+		return dto.JobDetailResponse{}, shared.ErrGettingJobs
+	}
+
+	response := dto.JobDetailResponse{
+		ID:         job.ID,
+		JobName:    job.JobName,
+		JobDesc:    job.JobDesc,
+		Quota:      job.Quota,
+		Status:     job.Status,
+		ExpiryDate: TimeToStrConv(job.ExpiryDate),
+		PostedAt:   TimeToStrConv(job.CreatedAt),
+		Version:    job.Version,
+		JobPoster: dto.JobPosterDTO{
+			ID:         job.JobPoster.ID,
+			Name:       job.JobPoster.Name,
+			CurrentJob: job.JobPoster.CurrentJob,
+		},
+	}
+
+	return response, nil
+}
+
+// CreateJobs posts a job for an organization. The poster, the principal
+// unless an admin names someone else, is recorded and must be a member.
+func (ju *jobUsecase) CreateJobs(ctx context.Context, newJob dto.JobsPayload, principal auth.Principal) (dto.JobsResponse, error) {
+	if newJob.JobPosterId == 0 {
+		newJob.JobPosterId = principal.UserId
+	}
+	if !principal.Owns(newJob.JobPosterId) {
+		return dto.JobsResponse{}, shared.ErrForbidden
+	}
+
+	_, err := ju.orgRepo.FindMembership(ctx, newJob.OrganizationId, newJob.JobPosterId)
+	if err != nil {
+		if !errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.JobsResponse{}, shared.ErrCheckingMembership
+		}
+		if newJob.JobPosterId == principal.UserId {
+			return dto.JobsResponse{}, shared.ErrForbidden
+		}
+		return dto.JobsResponse{}, shared.ErrPosterNotMember
+	}
+
+	expiryDate, err := parseExpiryDate("expiry_date", newJob.ExpiryDate, true, time.Now())
+	if err != nil {
+		return dto.JobsResponse{}, err
 	}
 
 	job := model.Jobs{
-		ID:          newJob.ID,
-		JobPosterId: newJob.JobPosterId,
-		JobName:     newJob.JobName,
-		JobDesc:     newJob.JobDesc,
-		Quota:       newJob.Quota,
-		IsOpen:      true,
-		ExpiryDate:  StrToTimeConv(newJob.ExpiryDate),
+		ID:             newJob.ID,
+		OrganizationId: newJob.OrganizationId,
+		JobPosterId:    newJob.JobPosterId,
+		JobName:        newJob.JobName,
+		JobDesc:        newJob.JobDesc,
+		Quota:          newJob.Quota,
+		Status:         model.JobStatusPublished,
+		ExpiryDate:     expiryDate,
+	}
+	if newJob.Draft {
+		job.Status = model.JobStatusDraft
 	}
 
-	modelJob, err := ju.jobRepo.Create(ctx, job)
+	modelJob, err := ju.jobRepo.Create(ctx, job, principal.UserId)
 	if err != nil {
 		return dto.JobsResponse{}, shared.ErrCreatingJobs
 	}
 
 	response := dto.JobsResponse{
-		ID:          modelJob.ID,
-		JobPosterId: modelJob.JobPosterId,
-		JobName:     modelJob.JobName,
-		JobDesc:     modelJob.JobDesc,
-		Quota:       modelJob.Quota,
-		ExpiryDate:  TimeToStrConv(modelJob.ExpiryDate),
+		ID:             modelJob.ID,
+		OrganizationId: modelJob.OrganizationId,
+		JobPosterId:    modelJob.JobPosterId,
+		JobName:        modelJob.JobName,
+		JobDesc:        modelJob.JobDesc,
+		Quota:          modelJob.Quota,
+		ExpiryDate:     TimeToStrConv(modelJob.ExpiryDate),
+		Version:        modelJob.Version,
 	}
 
 	return response, nil
 }
 
-func (ju *jobUsecase) CloseJob(ctx context.Context, closeJob dto.CloseJobsResponse, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	if closeJob.JobPosterId != jobPosterId {
-		return dto.CloseJobsResponse{}, shared.ErrUnauthorized
+func (ju *jobUsecase) ChangeJobStatus(ctx context.Context, jobId int, action string, principal auth.Principal) (dto.CloseJobsResponse, error) {
+	transition, ok := jobTransitions[action]
+	if !ok {
+		return dto.CloseJobsResponse{}, shared.ErrUnknownJobAction
+	}
+
+	modelJob, err := ju.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.CloseJobsResponse{}, shared.ErrJobNotFound
+		}
+		return dto.CloseJobsResponse{}, shared.ErrFindingJobs
+	}
+
+	if err := checkMember(ctx, ju.orgRepo, modelJob.OrganizationId, principal); err != nil {
+		return dto.CloseJobsResponse{}, err
 	}
 
-	modelJob := model.Jobs{
-		ID:          closeJob.ID,
-		JobPosterId: closeJob.JobPosterId,
-		JobName:     closeJob.JobName,
-		JobDesc:     closeJob.JobDesc,
-		Quota:       closeJob.Quota,
-		ExpiryDate:  StrToTimeConv(closeJob.ExpiryDate),
+	if !transition.allows(modelJob.Status) {
+		return dto.CloseJobsResponse{}, shared.ErrIllegalTransition
 	}
 
-	job, err := ju.jobRepo.Delete(ctx, modelJob)
+	job, err := ju.jobRepo.UpdateStatus(ctx, modelJob, transition.to, principal.UserId, action)
 	if err != nil {
-		return dto.CloseJobsResponse{}, shared.ErrFindingJobs
+		if errors.Is(err, shared.ErrJobStatusConflict) {
+			return dto.CloseJobsResponse{}, shared.ErrJobStatusConflict
+		}
+		return dto.CloseJobsResponse{}, shared.ErrUpdatingJobStatus
 	}
 
-	response := dto.CloseJobsResponse{
-		ID:          job.ID,
-		JobPosterId: job.JobPosterId,
-		JobName:     job.JobName,
-		JobDesc:     job.JobDesc,
-		Quota:       job.Quota,
-		IsOpen:      job.IsOpen,
-		ExpiryDate:  TimeToStrConv(job.ExpiryDate),
+	return toCloseJobsResponse(job), nil
+}
+
+func (ju *jobUsecase) GetJobStatusHistory(ctx context.Context, jobId int, principal auth.Principal) ([]dto.JobStatusHistoryDTO, error) {
+	histories := []dto.JobStatusHistoryDTO{}
+
+	job, err := ju.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return nil, shared.ErrJobNotFound
+		}
+		return nil, shared.ErrFindingJobs
 	}
 
-	return response, nil
+	if err := checkMember(ctx, ju.orgRepo, job.OrganizationId, principal); err != nil {
+		return nil, err
+	}
+
+	historyList, err := ju.jobRepo.FindStatusHistory(ctx, jobId)
+	if err != nil {
+		return nil, shared.ErrFindingJobs
+	}
+
+	for _, h := range historyList {
+		histories = append(histories, dto.JobStatusHistoryDTO{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			ChangedBy:  h.ChangedBy,
+			Reason:     h.Reason,
+			ChangedAt:  TimeToStrConv(h.CreatedAt),
+		})
+	}
+
+	return histories, nil
+}
+
+// PatchJob applies patch to the job. versions are the versions the caller's
+// copy of the job may have, as sent in If-Match; nil accepts any version.
+// Only drafts and published or paused jobs can be edited; a closed job has
+// to be reopened first.
+func (ju *jobUsecase) PatchJob(ctx context.Context, jobId int, patch dto.JobPatch, versions []int, principal auth.Principal) (dto.CloseJobsResponse, error) {
+	job, err := ju.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.CloseJobsResponse{}, shared.ErrJobNotFound
+		}
+		return dto.CloseJobsResponse{}, shared.ErrUpdatingJob
+	}
+	if err := checkMember(ctx, ju.orgRepo, job.OrganizationId, principal); err != nil {
+		return dto.CloseJobsResponse{}, err
+	}
+	if !jobEditable(job.Status) {
+		return dto.CloseJobsResponse{}, shared.ErrIllegalTransition
+	}
+
+	fields := map[string]interface{}{}
+	if patch.JobName != nil {
+		fields["job_name"] = *patch.JobName
+	}
+	if patch.JobDesc != nil {
+		fields["job_desc"] = *patch.JobDesc
+	}
+	if patch.Quota != nil {
+		fields["quota"] = *patch.Quota
+	}
+	if patch.ExpiryDate != nil {
+		expiryDate, err := parseExpiryDate("expiry_date", *patch.ExpiryDate, true, time.Now())
+		if err != nil {
+			return dto.CloseJobsResponse{}, err
+		}
+		fields["expiry_date"] = expiryDate
+	}
+
+	updated, err := ju.jobRepo.Update(ctx, job, fields, principal.UserId, versions)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.CloseJobsResponse{}, shared.ErrJobNotFound
+		}
+		if errors.Is(err, shared.ErrJobVersionMismatch) {
+			return dto.CloseJobsResponse{}, shared.ErrJobVersionMismatch
+		}
+		if errors.Is(err, shared.ErrJobStatusConflict) {
+			return dto.CloseJobsResponse{}, shared.ErrJobStatusConflict
+		}
+		return dto.CloseJobsResponse{}, shared.ErrUpdatingJob
+	}
+
+	return toCloseJobsResponse(updated), nil
 }
 
-func (ju *jobUsecase) UpdateQuota(ctx context.Context, updateJob dto.CloseJobsResponse, quota int, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	if updateJob.JobPosterId != jobPosterId {
-		return dto.CloseJobsResponse{}, shared.ErrUnauthorized
+// GetJobRevisions lists the revisions of a job, oldest first, each with what
+// changed since the revision before it.
+func (ju *jobUsecase) GetJobRevisions(ctx context.Context, jobId int, query dto.PaginationQuery, principal auth.Principal) ([]dto.JobRevisionDTO, dto.PaginationResponse, error) {
+	query.Normalize()
+
+	if err := ju.checkRevisionsVisible(ctx, jobId, principal); err != nil {
+		return nil, dto.PaginationResponse{}, err
 	}
 
-	modelJob := model.Jobs{
-		ID:          updateJob.ID,
-		JobPosterId: updateJob.JobPosterId,
-		JobName:     updateJob.JobName,
-		JobDesc:     updateJob.JobDesc,
-		Quota:       updateJob.Quota,
-		ExpiryDate:  StrToTimeConv(updateJob.ExpiryDate),
+	revisionList, total, err := ju.jobRepo.FindRevisions(ctx, jobId, query)
+	if err != nil {
+		return nil, dto.PaginationResponse{}, shared.ErrGettingJobRevisions
 	}
 
-	if quota < 0 {
-		return dto.CloseJobsResponse{}, shared.ErrMinusQuota
+	revisions := []dto.JobRevisionDTO{}
+	var previous *model.JobRevisions
+	if len(revisionList) > 0 {
+		before, err := ju.jobRepo.FindPreviousRevision(ctx, jobId, revisionList[0].Version)
+		if err != nil && !errors.Is(err, shared.ErrRecordNotFound) {
+			return nil, dto.PaginationResponse{}, shared.ErrGettingJobRevisions
+		}
+		if err == nil {
+			previous = &before
+		}
+	}
+	for i, r := range revisionList {
+		revisions = append(revisions, dto.JobRevisionDTO{
+			Version:   r.Version,
+			ChangedBy: r.ChangedBy,
+			Reason:    r.Reason,
+			ChangedAt: TimeToStrConv(r.CreatedAt),
+			Changes:   diffJobRevisions(previous, r),
+		})
+		previous = &revisionList[i]
+	}
+
+	return revisions, dto.NewPaginationResponse(query, total), nil
+}
+
+// DiffJobRevisions compares two revisions of a job; from may come after to.
+func (ju *jobUsecase) DiffJobRevisions(ctx context.Context, jobId int, query dto.JobRevisionDiffQuery, principal auth.Principal) (dto.JobRevisionDiffDTO, error) {
+	if err := ju.checkRevisionsVisible(ctx, jobId, principal); err != nil {
+		return dto.JobRevisionDiffDTO{}, err
 	}
 
-	job, err := ju.jobRepo.UpdateQuota(ctx, modelJob, quota)
+	from, err := ju.findRevision(ctx, jobId, query.From)
 	if err != nil {
-		return dto.CloseJobsResponse{}, shared.ErrFindingJobs
+		return dto.JobRevisionDiffDTO{}, err
+	}
+	to, err := ju.findRevision(ctx, jobId, query.To)
+	if err != nil {
+		return dto.JobRevisionDiffDTO{}, err
 	}
 
-	response := dto.CloseJobsResponse{
-		ID:          job.ID,
-		JobPosterId: job.JobPosterId,
-		JobName:     job.JobName,
-		JobDesc:     job.JobDesc,
-		Quota:       job.Quota,
-		IsOpen:      job.IsOpen,
-		ExpiryDate:  TimeToStrConv(job.ExpiryDate),
+	return dto.JobRevisionDiffDTO{
+		JobId:   uint(jobId),
+		From:    from.Version,
+		To:      to.Version,
+		Changes: diffJobRevisions(&from, to),
+	}, nil
+}
+
+// checkRevisionsVisible lets anyone read the revisions of a job that was
+// posted; those of a draft are only for the members of its organization.
+func (ju *jobUsecase) checkRevisionsVisible(ctx context.Context, jobId int, principal auth.Principal) error {
+	job, err := ju.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrJobNotFound
+		}
+		return shared.ErrGettingJobRevisions
+	}
+	if job.Status != model.JobStatusDraft {
+		return nil
 	}
 
-	return response, nil
+	member, err := isMember(ctx, ju.orgRepo, job.OrganizationId, principal)
+	if err != nil {
+		return shared.ErrCheckingMembership
+	}
+	if !member {
+		return shared.ErrJobNotFound
+	}
+	return nil
+}
+
+func (ju *jobUsecase) findRevision(ctx context.Context, jobId int, version int) (model.JobRevisions, error) {
+	revision, err := ju.jobRepo.FindRevision(ctx, jobId, version)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return model.JobRevisions{}, shared.ErrJobRevisionNotFound
+		}
+		return model.JobRevisions{}, shared.ErrGettingJobRevisions
+	}
+	return revision, nil
+}
+
+// diffJobRevisions lists the fields that differ from before to after. A nil
+// before reports every field, as when the job was created.
+func diffJobRevisions(before *model.JobRevisions, after model.JobRevisions) []dto.JobFieldChange {
+	type field struct {
+		name          string
+		before, after interface{}
+	}
+	fields := []field{
+		{name: "job_name", after: after.JobName},
+		{name: "job_desc", after: after.JobDesc},
+		{name: "quota", after: after.Quota},
+		{name: "status", after: after.Status},
+		{name: "expiry_date", after: TimeToStrConv(after.ExpiryDate)},
+	}
+	if before != nil {
+		fields[0].before = before.JobName
+		fields[1].before = before.JobDesc
+		fields[2].before = before.Quota
+		fields[3].before = before.Status
+		fields[4].before = TimeToStrConv(before.ExpiryDate)
+	}
+
+	changes := []dto.JobFieldChange{}
+	for _, f := range fields {
+		if f.before != f.after {
+			changes = append(changes, dto.JobFieldChange{Field: f.name, Before: f.before, After: f.after})
+		}
+	}
+	return changes
 }
 
-func (ju *jobUsecase) UpdateExpDate(ctx context.Context, updateJob dto.CloseJobsResponse, expDate string, jobPosterId uint) (dto.CloseJobsResponse, error) {
-	if updateJob.JobPosterId != jobPosterId {
-		return dto.CloseJobsResponse{}, shared.ErrUnauthorized
+func (ju *jobUsecase) DeleteJob(ctx context.Context, jobId int, principal auth.Principal) error {
+	job, err := ju.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrJobNotFound
+		}
+		return shared.ErrDeletingJob
+	}
+	if err := checkMember(ctx, ju.orgRepo, job.OrganizationId, principal); err != nil {
+		return err
 	}
 
-	modelJob := model.Jobs{
-		ID:          updateJob.ID,
-		JobPosterId: updateJob.JobPosterId,
-		JobName:     updateJob.JobName,
-		JobDesc:     updateJob.JobDesc,
-		Quota:       updateJob.Quota,
-		ExpiryDate:  StrToTimeConv(updateJob.ExpiryDate),
+	if err := ju.jobRepo.Delete(ctx, job); err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrJobNotFound
+		}
+		return shared.ErrDeletingJob
 	}
 
-	job, err := ju.jobRepo.UpdateExpDate(ctx, modelJob, StrToTimeConv(expDate))
+	return nil
+}
+
+func (ju *jobUsecase) GetDeletedJobs(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedJobDTO, dto.PaginationResponse, error) {
+	query.Normalize()
+
+	deleted, total, err := ju.jobRepo.FindDeleted(ctx, query)
 	if err != nil {
-		return dto.CloseJobsResponse{}, shared.ErrFindingJobs
+		return nil, dto.PaginationResponse{}, shared.ErrGettingDeletedRecords
+	}
+
+	jobs := []dto.DeletedJobDTO{}
+	for _, job := range deleted {
+		jobs = append(jobs, toDeletedJobDTO(job))
+	}
+
+	return jobs, dto.NewPaginationResponse(query, total), nil
+}
+
+func (ju *jobUsecase) RestoreJob(ctx context.Context, jobId int) (dto.DeletedJobDTO, error) {
+	job, err := ju.jobRepo.Restore(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.DeletedJobDTO{}, shared.ErrJobNotFound
+		}
+		if errors.Is(err, shared.ErrJobOrganizationMissing) {
+			return dto.DeletedJobDTO{}, shared.ErrJobOrganizationMissing
+		}
+		return dto.DeletedJobDTO{}, shared.ErrRestoringJob
 	}
 
-	response := dto.CloseJobsResponse{
+	return toDeletedJobDTO(job), nil
+}
+
+func toCloseJobsResponse(job model.Jobs) dto.CloseJobsResponse {
+	return dto.CloseJobsResponse{
+		ID:             job.ID,
+		OrganizationId: job.OrganizationId,
+		JobPosterId:    job.JobPosterId,
+		JobName:        job.JobName,
+		JobDesc:        job.JobDesc,
+		Quota:          job.Quota,
+		Status:         job.Status,
+		ExpiryDate:     TimeToStrConv(job.ExpiryDate),
+		Version:        job.Version,
+	}
+}
+
+func toDeletedJobDTO(job model.Jobs) dto.DeletedJobDTO {
+	return dto.DeletedJobDTO{
 		ID:          job.ID,
 		JobPosterId: job.JobPosterId,
 		JobName:     job.JobName,
-		JobDesc:     job.JobDesc,
-		Quota:       job.Quota,
-		IsOpen:      job.IsOpen,
+		Status:      job.Status,
 		ExpiryDate:  TimeToStrConv(job.ExpiryDate),
+		DeletedAt:   DeletedAtStrConv(job.DeletedAt),
 	}
-
-	return response, nil
 }
 
 func TimeToStrConv(dateTime time.Time) string {
-	return dateTime.Format("2006-01-02 15:04:05")
+	return dateTime.Format(dto.DateTimeLayout)
 }
 
-func StrToTimeConv(dateString string) time.Time {
-	layoutFormat := "2006-01-02 15:04:05"
-	date, _ := time.Parse(layoutFormat, dateString)
-	return date
+// DeletedAtStrConv formats a soft delete timestamp, or returns "" for a row
+// that is not deleted.
+func DeletedAtStrConv(deletedAt gorm.DeletedAt) string {
+	if !deletedAt.Valid {
+		return ""
+	}
+	return TimeToStrConv(deletedAt.Time)
+}
+
+func StrToTimeConv(dateString string) (time.Time, error) {
+	return time.Parse(dto.DateTimeLayout, dateString)
+}
+
+// parseExpiryDate parses the expiry date sent as field and, when future is
+// set, requires it to lie after now. Failures are reported as a
+// *shared.ValidationError on field.
+func parseExpiryDate(field string, value string, future bool, now time.Time) (time.Time, error) {
+	date, err := StrToTimeConv(value)
+	if err != nil {
+		return time.Time{}, shared.NewValidationError(validation.NewFieldError(field, validation.CodeInvalidDateTime, dto.DateTimeLayout))
+	}
+	if future && !date.After(now) {
+		return time.Time{}, shared.NewValidationError(validation.NewFieldError(field, validation.CodeNotInFuture))
+	}
+	return date, nil
 }
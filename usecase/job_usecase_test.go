@@ -0,0 +1,467 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/usecase"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobUsecase_ChangeJobStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		action   string
+		from     string
+		to       string
+		expected error
+	}{
+		{name: "publish draft", action: usecase.JobActionPublish, from: model.JobStatusDraft, to: model.JobStatusPublished},
+		{name: "pause published", action: usecase.JobActionPause, from: model.JobStatusPublished, to: model.JobStatusPaused},
+		{name: "resume paused", action: usecase.JobActionResume, from: model.JobStatusPaused, to: model.JobStatusPublished},
+		{name: "close paused", action: usecase.JobActionClose, from: model.JobStatusPaused, to: model.JobStatusClosed},
+		{name: "reopen closed", action: usecase.JobActionReopen, from: model.JobStatusClosed, to: model.JobStatusPublished},
+		{name: "archive closed", action: usecase.JobActionArchive, from: model.JobStatusClosed, to: model.JobStatusArchived},
+		{name: "pause draft", action: usecase.JobActionPause, from: model.JobStatusDraft, expected: shared.ErrIllegalTransition},
+		{name: "reopen archived", action: usecase.JobActionReopen, from: model.JobStatusArchived, expected: shared.ErrIllegalTransition},
+		{name: "unknown action", action: "delete", from: model.JobStatusPublished, expected: shared.ErrUnknownJobAction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobRepo := new(mocks.JobRepository)
+			mockOrgRepo := new(mocks.OrganizationRepository)
+			ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+			job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 2, Status: tt.from}
+			mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+			mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+			updated := job
+			updated.Status = tt.to
+			mockJobRepo.On("UpdateStatus", mock.Anything, job, tt.to, uint(2), tt.action).Return(updated, nil)
+
+			res, err := ju.ChangeJobStatus(context.Background(), 1, tt.action, auth.NewPrincipal(2, auth.RolePoster))
+
+			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.Equal(t, tt.to, res.Status)
+			} else {
+				mockJobRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+
+	t.Run("should reject a poster outside the job's organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 3, Status: model.JobStatusPublished}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := ju.ChangeJobStatus(context.Background(), 1, usecase.JobActionClose, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+	})
+
+	t.Run("should let any member of the organization act on its job", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 3, Status: model.JobStatusPublished}
+		closed := job
+		closed.Status = model.JobStatusClosed
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		mockJobRepo.On("UpdateStatus", mock.Anything, job, model.JobStatusClosed, uint(2), usecase.JobActionClose).Return(closed, nil)
+
+		res, err := ju.ChangeJobStatus(context.Background(), 1, usecase.JobActionClose, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobStatusClosed, res.Status)
+		assert.Equal(t, uint(3), res.JobPosterId)
+	})
+
+	t.Run("should report a failed membership check", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(model.Jobs{ID: 1, OrganizationId: 5, Status: model.JobStatusPublished}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{}, errors.New("connection reset"))
+
+		_, err := ju.ChangeJobStatus(context.Background(), 1, usecase.JobActionClose, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrCheckingMembership, err)
+	})
+
+	t.Run("should let an admin act on any job", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 3, Status: model.JobStatusPublished}
+		closed := job
+		closed.Status = model.JobStatusClosed
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockJobRepo.On("UpdateStatus", mock.Anything, job, model.JobStatusClosed, uint(9), usecase.JobActionClose).Return(closed, nil)
+
+		res, err := ju.ChangeJobStatus(context.Background(), 1, usecase.JobActionClose, auth.NewPrincipal(9, auth.RoleAdmin))
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.JobStatusClosed, res.Status)
+	})
+}
+
+func TestJobUsecase_CreateJobs(t *testing.T) {
+	payload := dto.JobsPayload{OrganizationId: 5, JobName: "backend", JobDesc: "golang", Quota: 3, ExpiryDate: "2099-01-01 00:00:00"}
+
+	t.Run("should post the job for the organization with the principal as poster", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		isNewJob := mock.MatchedBy(func(job model.Jobs) bool {
+			return job.OrganizationId == 5 && job.JobPosterId == 2 && job.Status == model.JobStatusPublished
+		})
+		mockJobRepo.On("Create", mock.Anything, isNewJob, uint(2)).Return(model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 2, JobName: "backend"}, nil)
+
+		res, err := ju.CreateJobs(context.Background(), payload, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.OrganizationId)
+		assert.Equal(t, uint(2), res.JobPosterId)
+	})
+
+	t.Run("should forbid posting for an organization the principal is not in", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := ju.CreateJobs(context.Background(), payload, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockJobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should forbid naming another poster", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		other := payload
+		other.JobPosterId = 3
+
+		_, err := ju.CreateJobs(context.Background(), other, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+	})
+
+	t.Run("should refuse an admin naming a poster outside the organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		other := payload
+		other.JobPosterId = 3
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(3)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := ju.CreateJobs(context.Background(), other, auth.NewPrincipal(9, auth.RoleAdmin))
+
+		assert.Equal(t, shared.ErrPosterNotMember, err)
+		mockJobRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobUsecase_DeleteJob(t *testing.T) {
+	t.Run("should soft delete a job of the poster's organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 2, Status: model.JobStatusPublished}
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		mockJobRepo.On("Delete", mock.Anything, job).Return(nil)
+
+		err := ju.DeleteJob(context.Background(), 1, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.NoError(t, err)
+		mockJobRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not delete a job of another organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(model.Jobs{ID: 1, OrganizationId: 6, JobPosterId: 3}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(6), uint(2)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		err := ju.DeleteJob(context.Background(), 1, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockJobRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobUsecase_RestoreJob(t *testing.T) {
+	t.Run("should return the restored job", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("Restore", mock.Anything, 1).Return(model.Jobs{ID: 1, JobPosterId: 2, Status: model.JobStatusClosed}, nil)
+
+		res, err := ju.RestoreJob(context.Background(), 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(1), res.ID)
+		assert.Empty(t, res.DeletedAt)
+	})
+
+	t.Run("should refuse while the organization is missing", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("Restore", mock.Anything, 1).Return(model.Jobs{}, shared.ErrJobOrganizationMissing)
+
+		_, err := ju.RestoreJob(context.Background(), 1)
+
+		assert.Equal(t, shared.ErrJobOrganizationMissing, err)
+	})
+
+	t.Run("should return not found for a job that is not deleted", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("Restore", mock.Anything, 1).Return(model.Jobs{}, shared.ErrRecordNotFound)
+
+		_, err := ju.RestoreJob(context.Background(), 1)
+
+		assert.Equal(t, shared.ErrJobNotFound, err)
+	})
+}
+
+func TestJobUsecase_PatchJob(t *testing.T) {
+	job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 2, JobName: "backend", Quota: 3, Status: model.JobStatusPublished, Version: 4}
+	poster := auth.NewPrincipal(2, auth.RolePoster)
+
+	t.Run("should update the patched fields at the expected version", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		name, quota := "golang", 5
+		updated := job
+		updated.JobName, updated.Quota, updated.Version = name, quota, 5
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		mockJobRepo.On("Update", mock.Anything, job, map[string]interface{}{"job_name": name, "quota": quota}, uint(2), []int{4}).Return(updated, nil)
+
+		res, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{JobName: &name, Quota: &quota}, []int{4}, poster)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "golang", res.JobName)
+		assert.Equal(t, 5, res.Quota)
+		assert.Equal(t, 5, res.Version)
+	})
+
+	t.Run("should not edit an archived job", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		archived := job
+		archived.Status = model.JobStatusArchived
+		quota := 5
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(archived, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+
+		_, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{Quota: &quota}, nil, poster)
+
+		assert.Equal(t, shared.ErrIllegalTransition, err)
+		mockJobRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should edit a draft", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		draft := job
+		draft.Status = model.JobStatusDraft
+		desc := "remote"
+		updated := draft
+		updated.JobDesc = desc
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(draft, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		mockJobRepo.On("Update", mock.Anything, draft, map[string]interface{}{"job_desc": desc}, uint(2), []int(nil)).Return(updated, nil)
+
+		res, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{JobDesc: &desc}, nil, poster)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "remote", res.JobDesc)
+	})
+
+	t.Run("should report a stale version", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		quota := 5
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+		mockJobRepo.On("Update", mock.Anything, job, mock.Anything, uint(2), []int{3}).Return(model.Jobs{}, shared.ErrJobVersionMismatch)
+
+		_, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{Quota: &quota}, []int{3}, poster)
+
+		assert.Equal(t, shared.ErrJobVersionMismatch, err)
+	})
+
+	t.Run("should forbid editing a job of another organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		quota := 5
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(9)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{Quota: &quota}, nil, auth.NewPrincipal(9, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockJobRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	tests := []struct {
+		name    string
+		expDate string
+		code    string
+	}{
+		{name: "should reject an unparsable date instead of using year 1", expDate: "next week", code: validation.CodeInvalidDateTime},
+		{name: "should reject a date in the past", expDate: "2000-01-01 00:00:00", code: validation.CodeNotInFuture},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockJobRepo := new(mocks.JobRepository)
+			mockOrgRepo := new(mocks.OrganizationRepository)
+			ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+			mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+			mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+
+			_, err := ju.PatchJob(context.Background(), 1, dto.JobPatch{ExpiryDate: &tt.expDate}, nil, poster)
+
+			var invalid *shared.ValidationError
+			require.ErrorAs(t, err, &invalid)
+			assert.Equal(t, "expiry_date", invalid.Fields[0].Field)
+			assert.Equal(t, tt.code, invalid.Fields[0].Code)
+			mockJobRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestJobUsecase_GetJobRevisions(t *testing.T) {
+	expiry := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := model.Jobs{ID: 1, OrganizationId: 5, JobPosterId: 2, Status: model.JobStatusPublished}
+	created := model.JobRevisions{JobId: 1, Version: 1, ChangedBy: 2, Reason: model.JobRevisionReasonCreated, JobName: "backend", Quota: 3, Status: model.JobStatusPublished, ExpiryDate: expiry}
+	edited := created
+	edited.Version, edited.Reason, edited.Quota = 2, model.JobRevisionReasonEdited, 5
+	paused := edited
+	paused.Version, paused.Reason, paused.Status = 4, usecase.JobActionPause, model.JobStatusPaused
+
+	t.Run("should report what changed since the revision before", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		query := dto.PaginationQuery{Page: 2, Limit: 2}
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockJobRepo.On("FindRevisions", mock.Anything, 1, query).Return([]model.JobRevisions{paused}, int64(3), nil)
+		mockJobRepo.On("FindPreviousRevision", mock.Anything, 1, 4).Return(edited, nil)
+
+		revisions, pagination, err := ju.GetJobRevisions(context.Background(), 1, query, auth.NewPrincipal(7, auth.RoleSeeker))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), pagination.TotalItems)
+		assert.Equal(t, []dto.JobRevisionDTO{{
+			Version:   4,
+			ChangedBy: 2,
+			Reason:    usecase.JobActionPause,
+			ChangedAt: usecase.TimeToStrConv(time.Time{}),
+			Changes:   []dto.JobFieldChange{{Field: "status", Before: model.JobStatusPublished, After: model.JobStatusPaused}},
+		}}, revisions)
+	})
+
+	t.Run("should report every field of the first revision", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockJobRepo.On("FindRevisions", mock.Anything, 1, mock.Anything).Return([]model.JobRevisions{created, edited}, int64(2), nil)
+		mockJobRepo.On("FindPreviousRevision", mock.Anything, 1, 1).Return(model.JobRevisions{}, shared.ErrRecordNotFound)
+
+		revisions, _, err := ju.GetJobRevisions(context.Background(), 1, dto.PaginationQuery{}, auth.NewPrincipal(7, auth.RoleSeeker))
+
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		assert.Len(t, revisions[0].Changes, 5)
+		assert.Nil(t, revisions[0].Changes[0].Before)
+		assert.Equal(t, []dto.JobFieldChange{{Field: "quota", Before: 3, After: 5}}, revisions[1].Changes)
+	})
+
+	t.Run("should hide the revisions of a draft from outside its organization", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		draft := job
+		draft.Status = model.JobStatusDraft
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(draft, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(7)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, _, err := ju.GetJobRevisions(context.Background(), 1, dto.PaginationQuery{}, auth.NewPrincipal(7, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrJobNotFound, err)
+		mockJobRepo.AssertNotCalled(t, "FindRevisions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestJobUsecase_DiffJobRevisions(t *testing.T) {
+	expiry := time.Date(2099, 1, 1, 0, 0, 0, 0, time.UTC)
+	job := model.Jobs{ID: 1, JobPosterId: 2, Status: model.JobStatusPublished}
+	from := model.JobRevisions{JobId: 1, Version: 1, JobName: "backend", Quota: 3, Status: model.JobStatusPublished, ExpiryDate: expiry}
+	to := from
+	to.Version, to.JobName, to.ExpiryDate = 6, "golang", expiry.AddDate(0, 1, 0)
+
+	t.Run("should list the fields that differ", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockJobRepo.On("FindRevision", mock.Anything, 1, 1).Return(from, nil)
+		mockJobRepo.On("FindRevision", mock.Anything, 1, 6).Return(to, nil)
+
+		diff, err := ju.DiffJobRevisions(context.Background(), 1, dto.JobRevisionDiffQuery{From: 1, To: 6}, auth.Principal{})
+
+		require.NoError(t, err)
+		assert.Equal(t, dto.JobRevisionDiffDTO{
+			JobId: 1,
+			From:  1,
+			To:    6,
+			Changes: []dto.JobFieldChange{
+				{Field: "job_name", Before: "backend", After: "golang"},
+				{Field: "expiry_date", Before: "2099-01-01 00:00:00", After: "2099-02-01 00:00:00"},
+			},
+		}, diff)
+	})
+
+	t.Run("should return error when a revision does not exist", func(t *testing.T) {
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ju := usecase.NewJobUsecase(mockJobRepo, new(mocks.JobSearcher), mockOrgRepo)
+		mockJobRepo.On("FindAnyById", mock.Anything, 1).Return(job, nil)
+		mockJobRepo.On("FindRevision", mock.Anything, 1, 1).Return(from, nil)
+		mockJobRepo.On("FindRevision", mock.Anything, 1, 9).Return(model.JobRevisions{}, shared.ErrRecordNotFound)
+
+		_, err := ju.DiffJobRevisions(context.Background(), 1, dto.JobRevisionDiffQuery{From: 1, To: 9}, auth.Principal{})
+
+		assert.Equal(t, shared.ErrJobRevisionNotFound, err)
+	})
+}
@@ -0,0 +1,285 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/repository"
+	"github.com/adityatresnobudi/job-portal/shared"
+)
+
+type organizationUsecase struct {
+	orgRepo  repository.OrganizationRepository
+	userRepo repository.UserRepository
+}
+
+type OrganizationUsecase interface {
+	CreateOrganization(ctx context.Context, payload dto.OrganizationPayload, principal auth.Principal) (dto.OrganizationResponse, error)
+	GetOrganizations(ctx context.Context, principal auth.Principal) ([]dto.OrganizationResponse, error)
+	GetOrganization(ctx context.Context, id int, principal auth.Principal) (dto.OrganizationDetailResponse, error)
+	AddMember(ctx context.Context, id int, payload dto.OrganizationMemberPayload, principal auth.Principal) (dto.OrganizationMemberDTO, error)
+	ChangeMemberRole(ctx context.Context, id int, userId int, payload dto.OrganizationRolePayload, principal auth.Principal) (dto.OrganizationMemberDTO, error)
+	RemoveMember(ctx context.Context, id int, userId int, principal auth.Principal) error
+}
+
+func NewOrganizationUsecase(orgRepo repository.OrganizationRepository, userRepo repository.UserRepository) OrganizationUsecase {
+	return &organizationUsecase{
+		orgRepo:  orgRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (ou *organizationUsecase) CreateOrganization(ctx context.Context, payload dto.OrganizationPayload, principal auth.Principal) (dto.OrganizationResponse, error) {
+	organization, err := ou.orgRepo.Create(ctx, model.Organizations{Name: payload.Name}, principal.UserId)
+	if err != nil {
+		return dto.OrganizationResponse{}, shared.ErrCreatingOrganization
+	}
+
+	return dto.OrganizationResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		Role:      model.OrganizationRoleOwner,
+		CreatedAt: TimeToStrConv(organization.CreatedAt),
+	}, nil
+}
+
+// GetOrganizations lists the organizations the principal is a member of.
+func (ou *organizationUsecase) GetOrganizations(ctx context.Context, principal auth.Principal) ([]dto.OrganizationResponse, error) {
+	organizations := []dto.OrganizationResponse{}
+
+	memberships, err := ou.orgRepo.FindByMember(ctx, principal.UserId)
+	if err != nil {
+		return nil, shared.ErrGettingOrganizations
+	}
+
+	for _, m := range memberships {
+		organizations = append(organizations, dto.OrganizationResponse{
+			ID:        m.Organization.ID,
+			Name:      m.Organization.Name,
+			Role:      m.Role,
+			CreatedAt: TimeToStrConv(m.Organization.CreatedAt),
+		})
+	}
+
+	return organizations, nil
+}
+
+// GetOrganization shows an organization and its members to its members.
+func (ou *organizationUsecase) GetOrganization(ctx context.Context, id int, principal auth.Principal) (dto.OrganizationDetailResponse, error) {
+	organization, err := ou.findOrganization(ctx, id)
+	if err != nil {
+		return dto.OrganizationDetailResponse{}, err
+	}
+	if err := checkMember(ctx, ou.orgRepo, organization.ID, principal); err != nil {
+		return dto.OrganizationDetailResponse{}, err
+	}
+
+	memberList, err := ou.orgRepo.FindMembers(ctx, organization.ID)
+	if err != nil {
+		return dto.OrganizationDetailResponse{}, shared.ErrGettingOrganizations
+	}
+
+	members := []dto.OrganizationMemberDTO{}
+	for _, m := range memberList {
+		members = append(members, toOrganizationMemberDTO(m))
+	}
+
+	return dto.OrganizationDetailResponse{
+		ID:        organization.ID,
+		Name:      organization.Name,
+		CreatedBy: organization.CreatedBy,
+		CreatedAt: TimeToStrConv(organization.CreatedAt),
+		Members:   members,
+	}, nil
+}
+
+func (ou *organizationUsecase) AddMember(ctx context.Context, id int, payload dto.OrganizationMemberPayload, principal auth.Principal) (dto.OrganizationMemberDTO, error) {
+	organization, err := ou.findOrganization(ctx, id)
+	if err != nil {
+		return dto.OrganizationMemberDTO{}, err
+	}
+	if err := ou.checkManager(ctx, organization.ID, payload.Role, principal); err != nil {
+		return dto.OrganizationMemberDTO{}, err
+	}
+
+	user, err := ou.userRepo.FindById(ctx, payload.UserId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.OrganizationMemberDTO{}, shared.ErrUserNotFound
+		}
+		return dto.OrganizationMemberDTO{}, shared.ErrUpdatingMembers
+	}
+
+	member, err := ou.orgRepo.AddMember(ctx, model.OrganizationMembers{
+		OrganizationId: organization.ID,
+		UserId:         user.ID,
+		Role:           payload.Role,
+	})
+	if err != nil {
+		if errors.Is(err, shared.ErrAlreadyMember) {
+			return dto.OrganizationMemberDTO{}, shared.ErrAlreadyMember
+		}
+		return dto.OrganizationMemberDTO{}, shared.ErrUpdatingMembers
+	}
+	member.User = user
+
+	return toOrganizationMemberDTO(member), nil
+}
+
+func (ou *organizationUsecase) ChangeMemberRole(ctx context.Context, id int, userId int, payload dto.OrganizationRolePayload, principal auth.Principal) (dto.OrganizationMemberDTO, error) {
+	organization, err := ou.findOrganization(ctx, id)
+	if err != nil {
+		return dto.OrganizationMemberDTO{}, err
+	}
+	member, err := ou.findMember(ctx, organization.ID, uint(userId))
+	if err != nil {
+		return dto.OrganizationMemberDTO{}, err
+	}
+	if err := ou.checkManager(ctx, organization.ID, mostPrivileged(member.Role, payload.Role), principal); err != nil {
+		return dto.OrganizationMemberDTO{}, err
+	}
+
+	updated, err := ou.orgRepo.UpdateMemberRole(ctx, organization.ID, member.UserId, payload.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, shared.ErrRecordNotFound):
+			return dto.OrganizationMemberDTO{}, shared.ErrMemberNotFound
+		case errors.Is(err, shared.ErrLastOwner):
+			return dto.OrganizationMemberDTO{}, shared.ErrLastOwner
+		}
+		return dto.OrganizationMemberDTO{}, shared.ErrUpdatingMembers
+	}
+
+	return toOrganizationMemberDTO(updated), nil
+}
+
+// RemoveMember takes a user out of an organization. Besides managers, any
+// member may remove themselves to leave it.
+func (ou *organizationUsecase) RemoveMember(ctx context.Context, id int, userId int, principal auth.Principal) error {
+	organization, err := ou.findOrganization(ctx, id)
+	if err != nil {
+		return err
+	}
+	member, err := ou.findMember(ctx, organization.ID, uint(userId))
+	if err != nil {
+		return err
+	}
+	if member.UserId != principal.UserId {
+		if err := ou.checkManager(ctx, organization.ID, member.Role, principal); err != nil {
+			return err
+		}
+	}
+
+	if err := ou.orgRepo.RemoveMember(ctx, organization.ID, member.UserId); err != nil {
+		switch {
+		case errors.Is(err, shared.ErrRecordNotFound):
+			return shared.ErrMemberNotFound
+		case errors.Is(err, shared.ErrLastOwner):
+			return shared.ErrLastOwner
+		}
+		return shared.ErrUpdatingMembers
+	}
+
+	return nil
+}
+
+func (ou *organizationUsecase) findOrganization(ctx context.Context, id int) (model.Organizations, error) {
+	organization, err := ou.orgRepo.FindById(ctx, uint(id))
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return model.Organizations{}, shared.ErrOrganizationNotFound
+		}
+		return model.Organizations{}, shared.ErrGettingOrganizations
+	}
+	return organization, nil
+}
+
+func (ou *organizationUsecase) findMember(ctx context.Context, organizationId uint, userId uint) (model.OrganizationMembers, error) {
+	member, err := ou.orgRepo.FindMembership(ctx, organizationId, userId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return model.OrganizationMembers{}, shared.ErrMemberNotFound
+		}
+		return model.OrganizationMembers{}, shared.ErrCheckingMembership
+	}
+	return member, nil
+}
+
+// checkManager fails with ErrForbidden unless the principal may manage
+// members holding role in the organization: owners and admins may, but only
+// owners may touch owners.
+func (ou *organizationUsecase) checkManager(ctx context.Context, organizationId uint, role string, principal auth.Principal) error {
+	if principal.IsAdmin() {
+		return nil
+	}
+
+	manager, err := ou.orgRepo.FindMembership(ctx, organizationId, principal.UserId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrForbidden
+		}
+		return shared.ErrCheckingMembership
+	}
+	if !manager.CanManageMembers() {
+		return shared.ErrForbidden
+	}
+	if role == model.OrganizationRoleOwner && manager.Role != model.OrganizationRoleOwner {
+		return shared.ErrForbidden
+	}
+
+	return nil
+}
+
+// mostPrivileged returns owner if either role is owner, so that changing a
+// role to or from owner needs an owner.
+func mostPrivileged(a string, b string) string {
+	if a == model.OrganizationRoleOwner || b == model.OrganizationRoleOwner {
+		return model.OrganizationRoleOwner
+	}
+	return a
+}
+
+// isMember reports whether the principal belongs to the organization.
+// Platform admins belong to every organization.
+func isMember(ctx context.Context, orgRepo repository.OrganizationRepository, organizationId uint, principal auth.Principal) (bool, error) {
+	if principal.IsAdmin() {
+		return true, nil
+	}
+	if principal.UserId == 0 {
+		return false, nil
+	}
+
+	_, err := orgRepo.FindMembership(ctx, organizationId, principal.UserId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// checkMember fails with ErrForbidden unless the principal belongs to the
+// organization. Any member may manage the jobs of an organization.
+func checkMember(ctx context.Context, orgRepo repository.OrganizationRepository, organizationId uint, principal auth.Principal) error {
+	member, err := isMember(ctx, orgRepo, organizationId, principal)
+	if err != nil {
+		return shared.ErrCheckingMembership
+	}
+	if !member {
+		return shared.ErrForbidden
+	}
+	return nil
+}
+
+func toOrganizationMemberDTO(m model.OrganizationMembers) dto.OrganizationMemberDTO {
+	return dto.OrganizationMemberDTO{
+		UserId:   m.UserId,
+		Name:     m.User.Name,
+		Role:     m.Role,
+		JoinedAt: TimeToStrConv(m.CreatedAt),
+	}
+}
@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrganizationUsecase_CreateOrganization(t *testing.T) {
+	t.Run("should make the creator the owner", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		creator := uint(2)
+		mockOrgRepo.On("Create", mock.Anything, model.Organizations{Name: "acme"}, uint(2)).Return(model.Organizations{ID: 5, Name: "acme", CreatedBy: &creator}, nil)
+
+		res, err := ou.CreateOrganization(context.Background(), dto.OrganizationPayload{Name: "acme"}, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), res.ID)
+		assert.Equal(t, model.OrganizationRoleOwner, res.Role)
+	})
+}
+
+func TestOrganizationUsecase_AddMember(t *testing.T) {
+	organization := model.Organizations{ID: 5, Name: "acme"}
+
+	t.Run("should let an admin of the organization add a recruiter", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, mockUserRepo)
+		member := model.OrganizationMembers{OrganizationId: 5, UserId: 3, Role: model.OrganizationRoleRecruiter}
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleAdmin}, nil)
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(model.Users{ID: 3, Name: "recruiter"}, nil)
+		mockOrgRepo.On("AddMember", mock.Anything, member).Return(member, nil)
+
+		res, err := ou.AddMember(context.Background(), 5, dto.OrganizationMemberPayload{UserId: 3, Role: model.OrganizationRoleRecruiter}, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, dto.OrganizationMemberDTO{UserId: 3, Name: "recruiter", Role: model.OrganizationRoleRecruiter, JoinedAt: usecase.TimeToStrConv(member.CreatedAt)}, res)
+	})
+
+	t.Run("should only let owners add owners", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleAdmin}, nil)
+
+		_, err := ou.AddMember(context.Background(), 5, dto.OrganizationMemberPayload{UserId: 3, Role: model.OrganizationRoleOwner}, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockOrgRepo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should not let a recruiter add members", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleRecruiter}, nil)
+
+		_, err := ou.AddMember(context.Background(), 5, dto.OrganizationMemberPayload{UserId: 3, Role: model.OrganizationRoleRecruiter}, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+	})
+
+	t.Run("should report a user who is already a member", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		mockUserRepo := new(mocks.UserRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, mockUserRepo)
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(model.Users{ID: 3}, nil)
+		mockOrgRepo.On("AddMember", mock.Anything, mock.Anything).Return(model.OrganizationMembers{}, shared.ErrAlreadyMember)
+
+		_, err := ou.AddMember(context.Background(), 5, dto.OrganizationMemberPayload{UserId: 3, Role: model.OrganizationRoleAdmin}, auth.NewPrincipal(9, auth.RoleAdmin))
+
+		assert.Equal(t, shared.ErrAlreadyMember, err)
+	})
+}
+
+func TestOrganizationUsecase_RemoveMember(t *testing.T) {
+	organization := model.Organizations{ID: 5, Name: "acme"}
+
+	t.Run("should let a member leave", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(3)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 3, Role: model.OrganizationRoleRecruiter}, nil)
+		mockOrgRepo.On("RemoveMember", mock.Anything, uint(5), uint(3)).Return(nil)
+
+		err := ou.RemoveMember(context.Background(), 5, 3, auth.NewPrincipal(3, auth.RolePoster))
+
+		assert.NoError(t, err)
+		mockOrgRepo.AssertExpectations(t)
+	})
+
+	t.Run("should keep the last owner", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleOwner}, nil)
+		mockOrgRepo.On("RemoveMember", mock.Anything, uint(5), uint(2)).Return(shared.ErrLastOwner)
+
+		err := ou.RemoveMember(context.Background(), 5, 2, auth.NewPrincipal(2, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrLastOwner, err)
+	})
+
+	t.Run("should not let an admin remove an owner", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(organization, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(2)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 2, Role: model.OrganizationRoleOwner}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(4)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 4, Role: model.OrganizationRoleAdmin}, nil)
+
+		err := ou.RemoveMember(context.Background(), 5, 2, auth.NewPrincipal(4, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockOrgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestOrganizationUsecase_GetOrganization(t *testing.T) {
+	t.Run("should hide an organization from non-members", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(model.Organizations{ID: 5}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(7)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := ou.GetOrganization(context.Background(), 5, auth.NewPrincipal(7, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockOrgRepo.AssertNotCalled(t, "FindMembers", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for a missing organization", func(t *testing.T) {
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		ou := usecase.NewOrganizationUsecase(mockOrgRepo, new(mocks.UserRepository))
+		mockOrgRepo.On("FindById", mock.Anything, uint(5)).Return(model.Organizations{}, shared.ErrRecordNotFound)
+
+		_, err := ou.GetOrganization(context.Background(), 5, auth.NewPrincipal(7, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrOrganizationNotFound, err)
+	})
+}
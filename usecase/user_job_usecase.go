@@ -2,68 +2,305 @@ package usecase
 
 import (
 	"context"
-	"time"
+	"errors"
 
+	"github.com/adityatresnobudi/job-portal/auth"
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/model"
 	"github.com/adityatresnobudi/job-portal/repository"
 	"github.com/adityatresnobudi/job-portal/shared"
 )
 
+// applicationPipeline lists, for every application status, the statuses the
+// job poster may move it to. Hired, rejected and withdrawn are final.
+var applicationPipeline = map[string][]string{
+	model.ApplicationStatusApplied:   {model.ApplicationStatusScreening, model.ApplicationStatusInterview, model.ApplicationStatusRejected},
+	model.ApplicationStatusScreening: {model.ApplicationStatusInterview, model.ApplicationStatusRejected},
+	model.ApplicationStatusInterview: {model.ApplicationStatusOffered, model.ApplicationStatusRejected},
+	model.ApplicationStatusOffered:   {model.ApplicationStatusHired, model.ApplicationStatusRejected},
+}
+
 type userJobUsecase struct {
 	userJobRepo repository.UserJobRepository
+	jobRepo     repository.JobRepository
+	orgRepo     repository.OrganizationRepository
 }
 
 type UserJobUsecase interface {
-	ApplyJob(ctx context.Context, job dto.UserJobsPayload, userId int) (dto.UserJobsDTO, error)
+	ApplyJob(ctx context.Context, job dto.UserJobsPayload, principal auth.Principal) (dto.UserJobsDTO, error)
+	GetApplications(ctx context.Context, principal auth.Principal, query dto.ApplicationsQuery) ([]dto.ApplicationResponse, dto.PaginationResponse, error)
+	GetApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error)
+	WithdrawApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error)
+	ChangeApplicationStatus(ctx context.Context, jobId int, id int, payload dto.ApplicationStatusPayload, principal auth.Principal) (dto.ApplicationResponse, error)
+	GetApplicationHistory(ctx context.Context, jobId int, id int, principal auth.Principal) ([]dto.ApplicationStatusHistoryDTO, error)
+	GetJobApplicants(ctx context.Context, jobId int, query dto.ApplicantsQuery, principal auth.Principal) ([]dto.ApplicantResponse, dto.PaginationResponse, error)
+	GetDeletedApplications(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedApplicationDTO, dto.PaginationResponse, error)
+	RestoreApplication(ctx context.Context, id int, principal auth.Principal) (dto.DeletedApplicationDTO, error)
 }
 
-func NewUserJobUsecase(userJobRepo repository.UserJobRepository) UserJobUsecase {
+func NewUserJobUsecase(userJobRepo repository.UserJobRepository, jobRepo repository.JobRepository, orgRepo repository.OrganizationRepository) UserJobUsecase {
 	return &userJobUsecase{
 		userJobRepo: userJobRepo,
+		jobRepo:     jobRepo,
+		orgRepo:     orgRepo,
 	}
 }
 
-func (uj *userJobUsecase) ApplyJob(ctx context.Context, job dto.UserJobsPayload, userId int) (dto.UserJobsDTO, error) {
-	if job.UserId != uint(userId) {
-		return dto.UserJobsDTO{}, shared.ErrUnauthorized
+func (uj *userJobUsecase) ApplyJob(ctx context.Context, job dto.UserJobsPayload, principal auth.Principal) (dto.UserJobsDTO, error) {
+	if job.UserId != principal.UserId {
+		return dto.UserJobsDTO{}, shared.ErrForbidden
+	}
+
+	res, err := uj.userJobRepo.Apply(ctx, principal.UserId, job.JobId)
+	if err != nil {
+		var ce *shared.CustomError
+		if errors.As(err, &ce) {
+			return dto.UserJobsDTO{}, ce
+		}
+		return dto.UserJobsDTO{}, shared.ErrCreateApplyJob
 	}
 
-	jobs, err := uj.userJobRepo.FindByJobIdUserId(ctx, int(job.JobId), userId)
+	userJobRes := dto.UserJobsDTO{
+		JobId:     res.JobId,
+		Status:    res.Status,
+		AppliedAt: TimeToStrConv(res.CreatedAt),
+	}
+
+	return userJobRes, nil
+}
+
+func (uj *userJobUsecase) GetApplications(ctx context.Context, principal auth.Principal, query dto.ApplicationsQuery) ([]dto.ApplicationResponse, dto.PaginationResponse, error) {
+	applications := []dto.ApplicationResponse{}
+	query.Normalize()
+
+	applicationList, total, err := uj.userJobRepo.FindByUserId(ctx, principal.UserId, query)
 	if err != nil {
-		return dto.UserJobsDTO{}, shared.ErrGettingUserJob
+		return nil, dto.PaginationResponse{}, shared.ErrGettingApplications
 	}
 
-	if len(jobs) != 0 {
-		return dto.UserJobsDTO{}, shared.ErrAlreadyApplied
+	for _, a := range applicationList {
+		applications = append(applications, toApplicationResponse(a))
 	}
 
-	j, err := uj.userJobRepo.FindById(ctx, int(job.JobId))
+	return applications, dto.NewPaginationResponse(query.PaginationQuery, total), nil
+}
+
+func (uj *userJobUsecase) GetApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error) {
+	application, err := uj.userJobRepo.FindByIdUserId(ctx, id, principal.UserId)
 	if err != nil {
-		return dto.UserJobsDTO{}, shared.ErrGettingUserJob
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.ApplicationResponse{}, shared.ErrApplicationNotFound
+		}
+		return dto.ApplicationResponse{}, shared.ErrGettingApplications
 	}
 
-	output, err := uj.userJobRepo.UpdateMinusOneQuota(ctx, j)
+	return toApplicationResponse(application), nil
+}
+
+func (uj *userJobUsecase) WithdrawApplication(ctx context.Context, id int, principal auth.Principal) (dto.ApplicationResponse, error) {
+	application, err := uj.userJobRepo.FindByIdUserId(ctx, id, principal.UserId)
 	if err != nil {
-		return dto.UserJobsDTO{}, shared.ErrJobTransaction
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.ApplicationResponse{}, shared.ErrApplicationNotFound
+		}
+		return dto.ApplicationResponse{}, shared.ErrGettingApplications
 	}
 
-	modelUserJob := model.UserJobs{
-		UserId: uint(userId),
-		JobId:  output.ID,
+	if !withdrawable(application.Status) {
+		return dto.ApplicationResponse{}, shared.ErrCannotWithdraw
 	}
 
-	res, err := uj.userJobRepo.Create(ctx, modelUserJob)
+	withdrawn, err := uj.userJobRepo.Withdraw(ctx, application)
 	if err != nil {
-		return dto.UserJobsDTO{}, shared.ErrCreateApplyJob
+		var ce *shared.CustomError
+		if errors.As(err, &ce) {
+			return dto.ApplicationResponse{}, ce
+		}
+		return dto.ApplicationResponse{}, shared.ErrWithdrawingApplication
 	}
 
-	userJobRes := dto.UserJobsDTO{
-		JobId:     res.JobId,
-		Status:    "Applied",
-		Message:   "Application success",
-		AppliedAt: TimeToStrConv(time.Now()),
+	return toApplicationResponse(withdrawn), nil
+}
+
+func (uj *userJobUsecase) ChangeApplicationStatus(ctx context.Context, jobId int, id int, payload dto.ApplicationStatusPayload, principal auth.Principal) (dto.ApplicationResponse, error) {
+	application, err := uj.findPosterApplication(ctx, jobId, id, principal)
+	if err != nil {
+		return dto.ApplicationResponse{}, err
 	}
 
-	return userJobRes, nil
+	if !canMoveApplication(application.Status, payload.Status) {
+		return dto.ApplicationResponse{}, shared.ErrIllegalApplicationTransition
+	}
+
+	updated, err := uj.userJobRepo.UpdateStatus(ctx, application, payload.Status, principal.UserId, payload.Note)
+	if err != nil {
+		var ce *shared.CustomError
+		if errors.As(err, &ce) {
+			return dto.ApplicationResponse{}, ce
+		}
+		return dto.ApplicationResponse{}, shared.ErrUpdatingApplicationStatus
+	}
+
+	return toApplicationResponse(updated), nil
+}
+
+func (uj *userJobUsecase) GetApplicationHistory(ctx context.Context, jobId int, id int, principal auth.Principal) ([]dto.ApplicationStatusHistoryDTO, error) {
+	histories := []dto.ApplicationStatusHistoryDTO{}
+
+	if _, err := uj.findPosterApplication(ctx, jobId, id, principal); err != nil {
+		return nil, err
+	}
+
+	historyList, err := uj.userJobRepo.FindStatusHistory(ctx, id)
+	if err != nil {
+		return nil, shared.ErrGettingApplications
+	}
+
+	for _, h := range historyList {
+		histories = append(histories, dto.ApplicationStatusHistoryDTO{
+			FromStatus: h.FromStatus,
+			ToStatus:   h.ToStatus,
+			ChangedBy:  h.ChangedBy,
+			Note:       h.Note,
+			ChangedAt:  TimeToStrConv(h.CreatedAt),
+		})
+	}
+
+	return histories, nil
+}
+
+func (uj *userJobUsecase) GetJobApplicants(ctx context.Context, jobId int, query dto.ApplicantsQuery, principal auth.Principal) ([]dto.ApplicantResponse, dto.PaginationResponse, error) {
+	applicants := []dto.ApplicantResponse{}
+	query.Normalize()
+
+	job, err := uj.jobRepo.FindAnyById(ctx, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return nil, dto.PaginationResponse{}, shared.ErrJobNotFound
+		}
+		return nil, dto.PaginationResponse{}, shared.ErrFindingJobs
+	}
+
+	if err := checkMember(ctx, uj.orgRepo, job.OrganizationId, principal); err != nil {
+		return nil, dto.PaginationResponse{}, err
+	}
+
+	applicationList, total, err := uj.userJobRepo.FindByJobId(ctx, jobId, query)
+	if err != nil {
+		return nil, dto.PaginationResponse{}, shared.ErrGettingApplications
+	}
+
+	for _, a := range applicationList {
+		applicants = append(applicants, dto.ApplicantResponse{
+			ApplicationId: a.ID,
+			Status:        a.Status,
+			AppliedAt:     TimeToStrConv(a.CreatedAt),
+			UpdatedAt:     TimeToStrConv(a.UpdatedAt),
+			Applicant: dto.ApplicantProfileDTO{
+				ID:         a.Users.ID,
+				Name:       a.Users.Name,
+				Email:      a.Users.Email,
+				Phone:      a.Users.Phone,
+				CurrentJob: a.Users.CurrentJob,
+				Age:        a.Users.Age,
+			},
+		})
+	}
+
+	return applicants, dto.NewPaginationResponse(query.PaginationQuery, total), nil
+}
+
+// findPosterApplication loads an application to jobId and checks that the
+// principal is a member of the organization owning that job.
+func (uj *userJobUsecase) findPosterApplication(ctx context.Context, jobId int, id int, principal auth.Principal) (model.UserJobs, error) {
+	application, err := uj.userJobRepo.FindByIdJobId(ctx, id, jobId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return model.UserJobs{}, shared.ErrApplicationNotFound
+		}
+		return model.UserJobs{}, shared.ErrGettingApplications
+	}
+
+	if err := checkMember(ctx, uj.orgRepo, application.Jobs.OrganizationId, principal); err != nil {
+		return model.UserJobs{}, err
+	}
+
+	return application, nil
+}
+
+func (uj *userJobUsecase) GetDeletedApplications(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedApplicationDTO, dto.PaginationResponse, error) {
+	query.Normalize()
+
+	deleted, total, err := uj.userJobRepo.FindDeleted(ctx, query)
+	if err != nil {
+		return nil, dto.PaginationResponse{}, shared.ErrGettingDeletedRecords
+	}
+
+	applications := []dto.DeletedApplicationDTO{}
+	for _, application := range deleted {
+		applications = append(applications, toDeletedApplicationDTO(application))
+	}
+
+	return applications, dto.NewPaginationResponse(query, total), nil
+}
+
+func (uj *userJobUsecase) RestoreApplication(ctx context.Context, id int, principal auth.Principal) (dto.DeletedApplicationDTO, error) {
+	application, err := uj.userJobRepo.Restore(ctx, id, principal.UserId)
+	if err != nil {
+		var ce *shared.CustomError
+		if errors.As(err, &ce) {
+			return dto.DeletedApplicationDTO{}, ce
+		}
+		return dto.DeletedApplicationDTO{}, shared.ErrRestoringApplication
+	}
+
+	return toDeletedApplicationDTO(application), nil
+}
+
+func toDeletedApplicationDTO(a model.UserJobs) dto.DeletedApplicationDTO {
+	return dto.DeletedApplicationDTO{
+		ID:        a.ID,
+		JobId:     a.JobId,
+		UserId:    a.UserId,
+		Status:    a.Status,
+		AppliedAt: TimeToStrConv(a.CreatedAt),
+		DeletedAt: DeletedAtStrConv(a.DeletedAt),
+	}
+}
+
+func canMoveApplication(from string, to string) bool {
+	for _, next := range applicationPipeline[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// withdrawable tells whether an applicant may still withdraw an application
+// in status. Once a hiring decision is made the application is final.
+func withdrawable(status string) bool {
+	for _, open := range model.OpenApplicationStatuses {
+		if open == status {
+			return true
+		}
+	}
+	return false
+}
+
+func toApplicationResponse(a model.UserJobs) dto.ApplicationResponse {
+	return dto.ApplicationResponse{
+		ID:        a.ID,
+		Status:    a.Status,
+		AppliedAt: TimeToStrConv(a.CreatedAt),
+		UpdatedAt: TimeToStrConv(a.UpdatedAt),
+		Job: dto.ApplicationJobDTO{
+			ID:          a.Jobs.ID,
+			JobPosterId: a.Jobs.JobPosterId,
+			JobName:     a.Jobs.JobName,
+			JobDesc:     a.Jobs.JobDesc,
+			Status:      a.Jobs.Status,
+			ExpiryDate:  TimeToStrConv(a.Jobs.ExpiryDate),
+		},
+	}
 }
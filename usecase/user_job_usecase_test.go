@@ -0,0 +1,150 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestUserJobUsecase_WithdrawApplication(t *testing.T) {
+	t.Run("should withdraw an application that is still open", func(t *testing.T) {
+		mockUserJobRepo := new(mocks.UserJobRepository)
+		uju := usecase.NewUserJobUsecase(mockUserJobRepo, new(mocks.JobRepository), new(mocks.OrganizationRepository))
+		application := model.UserJobs{ID: 1, JobId: 2, UserId: 3, Status: model.ApplicationStatusApplied}
+		withdrawn := application
+		withdrawn.Status = model.ApplicationStatusWithdrawn
+		mockUserJobRepo.On("FindByIdUserId", mock.Anything, 1, uint(3)).Return(application, nil)
+		mockUserJobRepo.On("Withdraw", mock.Anything, application).Return(withdrawn, nil)
+
+		res, err := uju.WithdrawApplication(context.Background(), 1, auth.NewPrincipal(3, auth.RoleSeeker))
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ApplicationStatusWithdrawn, res.Status)
+	})
+
+	t.Run("should not withdraw an application twice", func(t *testing.T) {
+		mockUserJobRepo := new(mocks.UserJobRepository)
+		uju := usecase.NewUserJobUsecase(mockUserJobRepo, new(mocks.JobRepository), new(mocks.OrganizationRepository))
+		application := model.UserJobs{ID: 1, JobId: 2, UserId: 3, Status: model.ApplicationStatusWithdrawn}
+		mockUserJobRepo.On("FindByIdUserId", mock.Anything, 1, uint(3)).Return(application, nil)
+
+		_, err := uju.WithdrawApplication(context.Background(), 1, auth.NewPrincipal(3, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrCannotWithdraw, err)
+		mockUserJobRepo.AssertNotCalled(t, "Withdraw", mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return not found for another applicant's application", func(t *testing.T) {
+		mockUserJobRepo := new(mocks.UserJobRepository)
+		uju := usecase.NewUserJobUsecase(mockUserJobRepo, new(mocks.JobRepository), new(mocks.OrganizationRepository))
+		mockUserJobRepo.On("FindByIdUserId", mock.Anything, 1, uint(3)).Return(model.UserJobs{}, shared.ErrRecordNotFound)
+
+		_, err := uju.WithdrawApplication(context.Background(), 1, auth.NewPrincipal(3, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrApplicationNotFound, err)
+	})
+}
+
+func TestUserJobUsecase_ChangeApplicationStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		expected error
+	}{
+		{name: "applied to screening", from: model.ApplicationStatusApplied, to: model.ApplicationStatusScreening},
+		{name: "interview to offered", from: model.ApplicationStatusInterview, to: model.ApplicationStatusOffered},
+		{name: "offered to hired", from: model.ApplicationStatusOffered, to: model.ApplicationStatusHired},
+		{name: "applied to hired", from: model.ApplicationStatusApplied, to: model.ApplicationStatusHired, expected: shared.ErrIllegalApplicationTransition},
+		{name: "rejected to interview", from: model.ApplicationStatusRejected, to: model.ApplicationStatusInterview, expected: shared.ErrIllegalApplicationTransition},
+		{name: "withdrawn to screening", from: model.ApplicationStatusWithdrawn, to: model.ApplicationStatusScreening, expected: shared.ErrIllegalApplicationTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockUserJobRepo := new(mocks.UserJobRepository)
+			mockOrgRepo := new(mocks.OrganizationRepository)
+			uju := usecase.NewUserJobUsecase(mockUserJobRepo, new(mocks.JobRepository), mockOrgRepo)
+			application := model.UserJobs{ID: 1, JobId: 2, UserId: 3, Status: tt.from, Jobs: model.Jobs{ID: 2, OrganizationId: 5, JobPosterId: 4}}
+			mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(4)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 4, Role: model.OrganizationRoleRecruiter}, nil)
+			updated := application
+			updated.Status = tt.to
+			mockUserJobRepo.On("FindByIdJobId", mock.Anything, 1, 2).Return(application, nil)
+			mockUserJobRepo.On("UpdateStatus", mock.Anything, application, tt.to, uint(4), "note").Return(updated, nil)
+
+			res, err := uju.ChangeApplicationStatus(context.Background(), 2, 1, dto.ApplicationStatusPayload{Status: tt.to, Note: "note"}, auth.NewPrincipal(4, auth.RolePoster))
+
+			assert.Equal(t, tt.expected, err)
+			if tt.expected == nil {
+				assert.Equal(t, tt.to, res.Status)
+			}
+		})
+	}
+
+	t.Run("should reject a poster outside the job's organization", func(t *testing.T) {
+		mockUserJobRepo := new(mocks.UserJobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		uju := usecase.NewUserJobUsecase(mockUserJobRepo, new(mocks.JobRepository), mockOrgRepo)
+		application := model.UserJobs{ID: 1, JobId: 2, UserId: 3, Status: model.ApplicationStatusApplied, Jobs: model.Jobs{ID: 2, OrganizationId: 5, JobPosterId: 4}}
+		mockUserJobRepo.On("FindByIdJobId", mock.Anything, 1, 2).Return(application, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(5)).Return(model.OrganizationMembers{}, shared.ErrRecordNotFound)
+
+		_, err := uju.ChangeApplicationStatus(context.Background(), 2, 1, dto.ApplicationStatusPayload{Status: model.ApplicationStatusScreening}, auth.NewPrincipal(5, auth.RolePoster))
+
+		assert.Equal(t, shared.ErrForbidden, err)
+		mockUserJobRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserJobUsecase_GetJobApplicants(t *testing.T) {
+	t.Run("should map applicants without their credentials", func(t *testing.T) {
+		mockUserJobRepo := new(mocks.UserJobRepository)
+		mockJobRepo := new(mocks.JobRepository)
+		mockOrgRepo := new(mocks.OrganizationRepository)
+		uju := usecase.NewUserJobUsecase(mockUserJobRepo, mockJobRepo, mockOrgRepo)
+		appliedAt := time.Date(2023, 6, 10, 20, 0, 0, 0, time.UTC)
+		application := model.UserJobs{
+			ID:        1,
+			JobId:     2,
+			UserId:    3,
+			Status:    model.ApplicationStatusInterview,
+			CreatedAt: appliedAt,
+			UpdatedAt: appliedAt.Add(24 * time.Hour),
+			Users: model.Users{
+				ID:         3,
+				Name:       "applicant",
+				Email:      "applicant@test.local",
+				Phone:      "0812",
+				CurrentJob: "engineer",
+				Age:        30,
+				Password:   "$2a$10$hashedpassword",
+			},
+		}
+		mockJobRepo.On("FindAnyById", mock.Anything, 2).Return(model.Jobs{ID: 2, OrganizationId: 5}, nil)
+		mockOrgRepo.On("FindMembership", mock.Anything, uint(5), uint(4)).Return(model.OrganizationMembers{OrganizationId: 5, UserId: 4, Role: model.OrganizationRoleRecruiter}, nil)
+		mockUserJobRepo.On("FindByJobId", mock.Anything, 2, mock.Anything).Return([]model.UserJobs{application}, int64(1), nil)
+
+		res, _, err := uju.GetJobApplicants(context.Background(), 2, dto.ApplicantsQuery{}, auth.NewPrincipal(4, auth.RolePoster))
+
+		assert.NoError(t, err)
+		assert.Equal(t, []dto.ApplicantResponse{{
+			ApplicationId: 1,
+			Status:        model.ApplicationStatusInterview,
+			AppliedAt:     usecase.TimeToStrConv(application.CreatedAt),
+			UpdatedAt:     usecase.TimeToStrConv(application.UpdatedAt),
+			Applicant:     dto.ApplicantProfileDTO{ID: 3, Name: "applicant", Email: "applicant@test.local", Phone: "0812", CurrentJob: "engineer", Age: 30},
+		}}, res)
+		body, _ := json.Marshal(res)
+		assert.NotContains(t, string(body), application.Users.Password)
+	})
+}
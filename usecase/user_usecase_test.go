@@ -0,0 +1,430 @@
+package usecase_test
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/helper"
+	"github.com/adityatresnobudi/job-portal/mocks"
+	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/notify"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func testJWT(t *testing.T) *helper.JWT {
+	cfg := config.Default().JWT
+	cfg.Secret = "0123456789abcdef0123456789abcdef"
+	tokens, err := helper.NewJWT(cfg)
+	require.NoError(t, err)
+	return tokens
+}
+
+func testEmail() config.Email {
+	cfg := config.Default().Email
+	cfg.VerifySecret = "0123456789abcdef0123456789abcdef"
+	return cfg
+}
+
+func TestUserUsecase_RefreshToken(t *testing.T) {
+	user := model.Users{ID: 3, Email: "seeker@mail.com", Role: auth.RoleSeeker}
+
+	t.Run("should rotate a valid refresh token within its family", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		current := model.RefreshTokens{ID: 1, UserId: 3, FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour)}
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("old")).Return(current, nil)
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockTokenRepo.On("RotateRefreshToken", mock.Anything, current, mock.MatchedBy(func(next model.RefreshTokens) bool {
+			return next.FamilyId == "family" && next.UserId == 3
+		})).Return(model.RefreshTokens{ID: 2}, nil)
+
+		res, err := uu.RefreshToken(context.Background(), dto.RefreshRequest{RefreshToken: "old"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.AccessToken)
+		assert.NotEmpty(t, res.RefreshToken)
+		assert.NotEqual(t, "old", res.RefreshToken)
+	})
+
+	t.Run("should report reuse of an already rotated refresh token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		revokedAt := time.Now().Add(-time.Minute)
+		current := model.RefreshTokens{ID: 1, UserId: 3, FamilyId: "family", ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("old")).Return(current, nil)
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockTokenRepo.On("RotateRefreshToken", mock.Anything, current, mock.Anything).Return(model.RefreshTokens{}, shared.ErrRefreshTokenReused)
+
+		res, err := uu.RefreshToken(context.Background(), dto.RefreshRequest{RefreshToken: "old"})
+
+		assert.Equal(t, shared.ErrRefreshTokenReused, err)
+		assert.Empty(t, res.AccessToken)
+	})
+
+	t.Run("should reject an expired refresh token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		current := model.RefreshTokens{ID: 1, UserId: 3, FamilyId: "family", ExpiresAt: time.Now().Add(-time.Hour)}
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("old")).Return(current, nil)
+
+		_, err := uu.RefreshToken(context.Background(), dto.RefreshRequest{RefreshToken: "old"})
+
+		assert.Equal(t, shared.ErrInvalidRefreshToken, err)
+		mockTokenRepo.AssertNotCalled(t, "RotateRefreshToken", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject an unknown refresh token", func(t *testing.T) {
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(new(mocks.UserRepository), mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("unknown")).Return(model.RefreshTokens{}, shared.ErrRecordNotFound)
+
+		_, err := uu.RefreshToken(context.Background(), dto.RefreshRequest{RefreshToken: "unknown"})
+
+		assert.Equal(t, shared.ErrInvalidRefreshToken, err)
+	})
+}
+
+func TestUserUsecase_Logout(t *testing.T) {
+	t.Run("should revoke the access token and the refresh token family", func(t *testing.T) {
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(new(mocks.UserRepository), mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		principal := auth.NewPrincipal(3, auth.RoleSeeker)
+		principal.TokenId = "jti"
+		principal.TokenExpiresAt = time.Now().Add(time.Hour)
+		mockTokenRepo.On("RevokeAccessToken", mock.Anything, "jti", principal.TokenExpiresAt).Return(nil)
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("refresh")).Return(model.RefreshTokens{UserId: 3, FamilyId: "family"}, nil)
+		mockTokenRepo.On("RevokeRefreshTokenFamily", mock.Anything, "family").Return(nil)
+
+		err := uu.Logout(context.Background(), dto.LogoutRequest{RefreshToken: "refresh"}, principal)
+
+		assert.NoError(t, err)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("should not revoke another user's refresh token", func(t *testing.T) {
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(new(mocks.UserRepository), mockTokenRepo, testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockTokenRepo.On("FindRefreshTokenByHash", mock.Anything, helper.HashToken("refresh")).Return(model.RefreshTokens{UserId: 4, FamilyId: "family"}, nil)
+
+		err := uu.Logout(context.Background(), dto.LogoutRequest{RefreshToken: "refresh"}, auth.NewPrincipal(3, auth.RoleSeeker))
+
+		assert.Equal(t, shared.ErrInvalidRefreshToken, err)
+		mockTokenRepo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_DeleteUser(t *testing.T) {
+	t.Run("should refuse to delete the last owner of an organization", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockUserRepo.On("Delete", mock.Anything, uint(3)).Return(shared.ErrLastOwner)
+
+		err := uu.DeleteUser(context.Background(), 3)
+
+		assert.Equal(t, shared.ErrLastOwner, err)
+	})
+}
+
+func TestUserUsecase_UpdateProfile(t *testing.T) {
+	user := model.Users{ID: 3, Name: "Seeker", Email: "seeker@mail.com", CurrentJob: "Barista"}
+
+	t.Run("should only write the fields present in the request", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		currentJob := ""
+		updated := user
+		updated.CurrentJob = ""
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockUserRepo.On("Update", mock.Anything, updated, []string{"CurrentJob"}).Return(updated, nil)
+
+		res, err := uu.UpdateProfile(context.Background(), 3, dto.UpdateProfileRequest{CurrentJob: &currentJob})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Seeker", res.Name)
+		assert.Empty(t, res.CurrentJob)
+		assert.Empty(t, res.PendingEmail)
+	})
+
+	t.Run("should keep the email until the new one is confirmed", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		mockNotifier := new(mocks.Notifier)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), mockNotifier, config.Default().Password, testEmail())
+		email := "new@mail.com"
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockUserRepo.On("FindByEmail", mock.Anything, email).Return(model.Users{}, shared.ErrRecordNotFound)
+		mockTokenRepo.On("CreateUserToken", mock.Anything, mock.MatchedBy(func(token model.UserTokens) bool {
+			return token.UserId == 3 && token.Email == email && token.Purpose == model.UserTokenPurposeEmailChange
+		})).Return(model.UserTokens{ID: 1}, nil)
+		mockNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+			return msg.To == email
+		})).Return(nil)
+
+		res, err := uu.UpdateProfile(context.Background(), 3, dto.UpdateProfileRequest{Email: &email})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "seeker@mail.com", res.Email)
+		assert.Equal(t, email, res.PendingEmail)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("should reject an email used by another account", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		email := "taken@mail.com"
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockUserRepo.On("FindByEmail", mock.Anything, email).Return(model.Users{ID: 4}, nil)
+
+		_, err := uu.UpdateProfile(context.Background(), 3, dto.UpdateProfileRequest{Email: &email})
+
+		assert.Equal(t, shared.ErrEmailTaken, err)
+	})
+}
+
+func TestUserUsecase_ConfirmEmail(t *testing.T) {
+	t.Run("should change the email with a valid token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockUserRepo.On("ChangeEmail", mock.Anything, helper.HashToken("token")).Return(model.Users{ID: 3, Email: "new@mail.com"}, nil)
+
+		res, err := uu.ConfirmEmail(context.Background(), dto.ConfirmEmailRequest{Token: "token"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "new@mail.com", res.Email)
+	})
+
+	t.Run("should reject an unknown, used or expired token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockUserRepo.On("ChangeEmail", mock.Anything, helper.HashToken("token")).Return(model.Users{}, shared.ErrRecordNotFound)
+
+		_, err := uu.ConfirmEmail(context.Background(), dto.ConfirmEmailRequest{Token: "token"})
+
+		assert.Equal(t, shared.ErrInvalidEmailToken, err)
+	})
+}
+
+func TestUserUsecase_LoginUser(t *testing.T) {
+	passwords := config.Password{BcryptCost: bcrypt.MinCost + 1}
+
+	t.Run("should rehash a password stored with a lower cost", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), passwords, testEmail())
+		hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+		user := model.Users{ID: 3, Email: "seeker@mail.com", Password: string(hash)}
+		mockUserRepo.On("FindByEmail", mock.Anything, "seeker@mail.com").Return(user, nil)
+		mockUserRepo.On("Update", mock.Anything, mock.MatchedBy(func(u model.Users) bool {
+			cost, _ := bcrypt.Cost([]byte(u.Password))
+			return u.ID == 3 && cost == passwords.BcryptCost && bcrypt.CompareHashAndPassword([]byte(u.Password), []byte("secret123")) == nil
+		}), []string{"Password"}).Return(user, nil)
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(model.RefreshTokens{ID: 1}, nil)
+
+		res, err := uu.LoginUser(context.Background(), dto.LoginRequest{Email: "seeker@mail.com", Password: "secret123"})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.AccessToken)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("should leave a hash with the configured cost alone", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), passwords, testEmail())
+		hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), passwords.BcryptCost)
+		mockUserRepo.On("FindByEmail", mock.Anything, "seeker@mail.com").Return(model.Users{ID: 3, Password: string(hash)}, nil)
+		mockTokenRepo.On("CreateRefreshToken", mock.Anything, mock.Anything).Return(model.RefreshTokens{ID: 1}, nil)
+
+		_, err := uu.LoginUser(context.Background(), dto.LoginRequest{Email: "seeker@mail.com", Password: "secret123"})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ChangePassword(t *testing.T) {
+	passwords := config.Password{BcryptCost: bcrypt.MinCost}
+	hash, _ := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	user := model.Users{ID: 3, Password: string(hash)}
+
+	principal := auth.Principal{UserId: 3, TokenId: "jti-1", TokenExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("should store the new password and revoke the current access token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), new(mocks.Notifier), passwords, testEmail())
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+		mockUserRepo.On("UpdatePassword", mock.Anything, uint(3), mock.MatchedBy(func(hash string) bool {
+			return bcrypt.CompareHashAndPassword([]byte(hash), []byte("newsecret")) == nil
+		})).Return(nil)
+		mockTokenRepo.On("RevokeAccessToken", mock.Anything, "jti-1", principal.TokenExpiresAt).Return(nil)
+
+		err := uu.ChangePassword(context.Background(), dto.ChangePasswordRequest{CurrentPassword: "secret123", NewPassword: "newsecret"}, principal)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockTokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("should reject a wrong current password", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), passwords, testEmail())
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(user, nil)
+
+		err := uu.ChangePassword(context.Background(), dto.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "newsecret"}, principal)
+
+		assert.Equal(t, shared.ErrWrongPassword, err)
+		mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ForgotPassword(t *testing.T) {
+	t.Run("should send a reset token to a known email", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockTokenRepo := new(mocks.TokenRepository)
+		mockNotifier := new(mocks.Notifier)
+		uu := usecase.NewUserUsecase(mockUserRepo, mockTokenRepo, testJWT(t), mockNotifier, config.Default().Password, testEmail())
+		mockUserRepo.On("FindByEmail", mock.Anything, "seeker@mail.com").Return(model.Users{ID: 3, Email: "seeker@mail.com"}, nil)
+		mockTokenRepo.On("CreateUserToken", mock.Anything, mock.MatchedBy(func(token model.UserTokens) bool {
+			return token.UserId == 3 && token.Purpose == model.UserTokenPurposePasswordReset && token.ExpiresAt.After(time.Now())
+		})).Return(model.UserTokens{ID: 1}, nil)
+		mockNotifier.On("Notify", mock.Anything, mock.MatchedBy(func(msg notify.Message) bool {
+			return msg.To == "seeker@mail.com"
+		})).Return(nil)
+
+		err := uu.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: "seeker@mail.com"})
+
+		assert.NoError(t, err)
+		mockNotifier.AssertExpectations(t)
+	})
+
+	t.Run("should not reveal that an email is unknown", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockNotifier := new(mocks.Notifier)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), mockNotifier, config.Default().Password, testEmail())
+		mockUserRepo.On("FindByEmail", mock.Anything, "nobody@mail.com").Return(model.Users{}, shared.ErrRecordNotFound)
+
+		err := uu.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: "nobody@mail.com"})
+
+		assert.NoError(t, err)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUserUsecase_ResetPassword(t *testing.T) {
+	t.Run("should reject an unknown, used or expired token", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Password{BcryptCost: bcrypt.MinCost}, testEmail())
+		mockUserRepo.On("ResetPassword", mock.Anything, helper.HashToken("token"), mock.Anything).Return(model.Users{}, shared.ErrRecordNotFound)
+
+		err := uu.ResetPassword(context.Background(), dto.ResetPasswordRequest{Token: "token", NewPassword: "newsecret"})
+
+		assert.Equal(t, shared.ErrInvalidPasswordResetToken, err)
+	})
+}
+
+func TestUserUsecase_CreateUsers(t *testing.T) {
+	t.Run("should create an unverified account and mail a verification link", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		notifier := notify.NewMemoryNotifier()
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), notifier, config.Password{BcryptCost: bcrypt.MinCost}, testEmail())
+		mockUserRepo.On("Create", mock.Anything, mock.MatchedBy(func(u model.Users) bool {
+			return u.Email == "seeker@mail.com" && u.EmailVerifiedAt == nil
+		})).Return(model.Users{ID: 3, Email: "seeker@mail.com"}, nil)
+
+		res, err := uu.CreateUsers(context.Background(), dto.UserPayload{Name: "Seeker", Email: "seeker@mail.com", Password: "secret123"})
+
+		assert.NoError(t, err)
+		assert.False(t, res.EmailVerified)
+		require.Len(t, notifier.Messages(), 1)
+		assert.Equal(t, "seeker@mail.com", notifier.Messages()[0].To)
+		assert.Contains(t, notifier.Messages()[0].Body, testEmail().VerifyURL+"?")
+	})
+}
+
+func TestUserUsecase_VerifyEmail(t *testing.T) {
+	user := model.Users{ID: 3, Email: "seeker@mail.com"}
+
+	// sentLink registers user and returns the query of the link mailed to them.
+	sentLink := func(t *testing.T) url.Values {
+		mockUserRepo := new(mocks.UserRepository)
+		notifier := notify.NewMemoryNotifier()
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), notifier, config.Password{BcryptCost: bcrypt.MinCost}, testEmail())
+		mockUserRepo.On("Create", mock.Anything, mock.Anything).Return(user, nil)
+		_, err := uu.CreateUsers(context.Background(), dto.UserPayload{Email: user.Email, Password: "secret123"})
+		require.NoError(t, err)
+
+		body := notifier.Messages()[0].Body
+		link, err := url.Parse(strings.Fields(body)[1])
+		require.NoError(t, err)
+		return link.Query()
+	}
+
+	t.Run("should verify the email of a mailed link", func(t *testing.T) {
+		link := sentLink(t)
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		verifiedAt := time.Now()
+		verified := user
+		verified.EmailVerifiedAt = &verifiedAt
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, uint(3), "seeker@mail.com").Return(verified, nil)
+
+		res, err := uu.VerifyEmail(context.Background(), link)
+
+		assert.NoError(t, err)
+		assert.True(t, res.EmailVerified)
+	})
+
+	t.Run("should reject a tampered link", func(t *testing.T) {
+		link := sentLink(t)
+		link.Set("user", "4")
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+
+		_, err := uu.VerifyEmail(context.Background(), link)
+
+		assert.Equal(t, shared.ErrInvalidVerificationLink, err)
+		mockUserRepo.AssertNotCalled(t, "MarkEmailVerified", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should reject a link for an email the user no longer has", func(t *testing.T) {
+		link := sentLink(t)
+		mockUserRepo := new(mocks.UserRepository)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), new(mocks.Notifier), config.Default().Password, testEmail())
+		mockUserRepo.On("MarkEmailVerified", mock.Anything, uint(3), "seeker@mail.com").Return(model.Users{}, shared.ErrRecordNotFound)
+
+		_, err := uu.VerifyEmail(context.Background(), link)
+
+		assert.Equal(t, shared.ErrInvalidVerificationLink, err)
+	})
+}
+
+func TestUserUsecase_ResendVerification(t *testing.T) {
+	t.Run("should not resend to a verified email", func(t *testing.T) {
+		mockUserRepo := new(mocks.UserRepository)
+		mockNotifier := new(mocks.Notifier)
+		uu := usecase.NewUserUsecase(mockUserRepo, new(mocks.TokenRepository), testJWT(t), mockNotifier, config.Default().Password, testEmail())
+		verifiedAt := time.Now()
+		mockUserRepo.On("FindById", mock.Anything, uint(3)).Return(model.Users{ID: 3, EmailVerifiedAt: &verifiedAt}, nil)
+
+		err := uu.ResendVerification(context.Background(), 3)
+
+		assert.Equal(t, shared.ErrEmailAlreadyVerified, err)
+		mockNotifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+	})
+}
@@ -3,33 +3,72 @@ package usecase
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strconv"
+	"time"
 
+	"github.com/adityatresnobudi/job-portal/auth"
+	"github.com/adityatresnobudi/job-portal/config"
 	"github.com/adityatresnobudi/job-portal/dto"
 	"github.com/adityatresnobudi/job-portal/helper"
 	"github.com/adityatresnobudi/job-portal/model"
+	"github.com/adityatresnobudi/job-portal/notify"
 	"github.com/adityatresnobudi/job-portal/repository"
 	"github.com/adityatresnobudi/job-portal/shared"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type userUsecase struct {
-	userRepo repository.UserRepository
+	userRepo  repository.UserRepository
+	tokenRepo repository.TokenRepository
+	tokens    *helper.JWT
+	notifier  notify.Notifier
+	passwords config.Password
+	email     config.Email
+	links     *helper.LinkSigner
 }
 
 type UserUsecase interface {
 	CreateUsers(ctx context.Context, user dto.UserPayload) (dto.UserResponse, error)
 	LoginUser(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error)
+	RefreshToken(ctx context.Context, req dto.RefreshRequest) (dto.LoginResponse, error)
+	Logout(ctx context.Context, req dto.LogoutRequest, principal auth.Principal) error
+	IsTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error)
+	DeleteUser(ctx context.Context, id uint) error
+	GetDeletedUsers(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedUserDTO, dto.PaginationResponse, error)
+	RestoreUser(ctx context.Context, id uint) (dto.DeletedUserDTO, error)
+	GetProfile(ctx context.Context, id uint) (dto.UserResponse, error)
+	UpdateProfile(ctx context.Context, id uint, req dto.UpdateProfileRequest) (dto.UpdateProfileResponse, error)
+	ConfirmEmail(ctx context.Context, req dto.ConfirmEmailRequest) (dto.UserResponse, error)
+	GetPublicProfile(ctx context.Context, id uint) (dto.PublicProfileResponse, error)
+	ChangePassword(ctx context.Context, req dto.ChangePasswordRequest, principal auth.Principal) error
+	ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error
+	VerifyEmail(ctx context.Context, link url.Values) (dto.UserResponse, error)
+	ResendVerification(ctx context.Context, id uint) error
 }
 
-func NewUserUsecase(userRepo repository.UserRepository) UserUsecase {
+const (
+	// emailTokenTTL is how long the link sent to a new email address works.
+	emailTokenTTL = 24 * time.Hour
+	// passwordResetTokenTTL is kept short since the token grants the account.
+	passwordResetTokenTTL = time.Hour
+)
+
+func NewUserUsecase(userRepo repository.UserRepository, tokenRepo repository.TokenRepository, tokens *helper.JWT, notifier notify.Notifier, passwords config.Password, email config.Email) UserUsecase {
 	return &userUsecase{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		tokens:    tokens,
+		notifier:  notifier,
+		passwords: passwords,
+		email:     email,
+		links:     helper.NewLinkSigner(email.VerifySecret.Value()),
 	}
 }
 
 func (uu *userUsecase) CreateUsers(ctx context.Context, user dto.UserPayload) (dto.UserResponse, error) {
 	newUser := model.Users{}
-	userRes := dto.UserResponse{}
 
 	newUser.ID = user.ID
 	newUser.Name = user.Name
@@ -38,27 +77,27 @@ func (uu *userUsecase) CreateUsers(ctx context.Context, user dto.UserPayload) (d
 	newUser.CurrentJob = user.CurrentJob
 	newUser.Age = user.Age
 	newUser.IsJobPoster = user.IsJobPoster
+	newUser.Role = auth.RoleSeeker
+	if user.IsJobPoster {
+		newUser.Role = auth.RolePoster
+	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(user.Password), bcrypt.MinCost)
+	hash, err := uu.hashPassword(user.Password)
 	if err != nil {
 		return dto.UserResponse{}, shared.ErrCreateUsers
 	}
-	newUser.Password = string(hash)
+	newUser.Password = hash
 
 	uc, err := uu.userRepo.Create(ctx, newUser)
 	if err != nil {
 		return dto.UserResponse{}, shared.ErrCreateUsers
 	}
 
-	userRes.ID = uc.ID
-	userRes.Name = uc.Name
-	userRes.Email = uc.Email
-	userRes.Phone = uc.Phone
-	userRes.CurrentJob = uc.CurrentJob
-	userRes.Age = uc.Age
-	userRes.IsJobPoster = uc.IsJobPoster
+	// The account exists at this point, so a failed send is not a failed
+	// registration; the user can ask for the link again.
+	uu.sendVerification(ctx, uc)
 
-	return userRes, nil
+	return toUserResponse(uc), nil
 }
 
 func (uu *userUsecase) LoginUser(ctx context.Context, req dto.LoginRequest) (dto.LoginResponse, error) {
@@ -66,19 +105,510 @@ func (uu *userUsecase) LoginUser(ctx context.Context, req dto.LoginRequest) (dto
 
 	user, err := uu.userRepo.FindByEmail(ctx, req.Email)
 	if err != nil || user.ID == 0 {
+		// Unknown emails and wrong passwords are reported alike so the
+		// response does not reveal which accounts exist.
 		if errors.Is(err, shared.ErrRecordNotFound) {
-			return output, shared.ErrUserDoesntExist
+			return output, shared.ErrInvalidCredentials
 		}
 		return output, shared.ErrFailedLogin
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
-		return output, shared.ErrInvalidPassword
+		return output, shared.ErrInvalidCredentials
+	}
+	uu.upgradePasswordHash(ctx, user, req.Password)
+
+	token, err := uu.accessToken(user)
+	if err != nil {
+		return output, shared.ErrFailedLogin
+	}
+
+	familyId, err := helper.RandomToken(16)
+	if err != nil {
+		return output, shared.ErrFailedLogin
+	}
+
+	refreshToken, refresh, err := uu.newRefreshToken(user.ID, familyId)
+	if err != nil {
+		return output, shared.ErrFailedLogin
+	}
+
+	if _, err := uu.tokenRepo.CreateRefreshToken(ctx, refresh); err != nil {
+		return output, shared.ErrFailedLogin
+	}
+
+	return uu.loginResponse(token, refreshToken), nil
+}
+
+func (uu *userUsecase) RefreshToken(ctx context.Context, req dto.RefreshRequest) (dto.LoginResponse, error) {
+	output := dto.LoginResponse{}
+
+	current, err := uu.tokenRepo.FindRefreshTokenByHash(ctx, helper.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return output, shared.ErrInvalidRefreshToken
+		}
+		return output, shared.ErrRefreshingToken
+	}
+
+	if current.RevokedAt == nil && !current.ExpiresAt.After(time.Now()) {
+		return output, shared.ErrInvalidRefreshToken
+	}
+
+	user, err := uu.userRepo.FindById(ctx, current.UserId)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return output, shared.ErrInvalidRefreshToken
+		}
+		return output, shared.ErrRefreshingToken
+	}
+
+	refreshToken, next, err := uu.newRefreshToken(user.ID, current.FamilyId)
+	if err != nil {
+		return output, shared.ErrRefreshingToken
+	}
+
+	if _, err := uu.tokenRepo.RotateRefreshToken(ctx, current, next); err != nil {
+		if errors.Is(err, shared.ErrRefreshTokenReused) {
+			return output, shared.ErrRefreshTokenReused
+		}
+		return output, shared.ErrRefreshingToken
+	}
+
+	token, err := uu.accessToken(user)
+	if err != nil {
+		return output, shared.ErrRefreshingToken
+	}
+
+	return uu.loginResponse(token, refreshToken), nil
+}
+
+func (uu *userUsecase) Logout(ctx context.Context, req dto.LogoutRequest, principal auth.Principal) error {
+	if principal.TokenId != "" {
+		if err := uu.tokenRepo.RevokeAccessToken(ctx, principal.TokenId, principal.TokenExpiresAt); err != nil {
+			return shared.ErrFailedLogout
+		}
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	refresh, err := uu.tokenRepo.FindRefreshTokenByHash(ctx, helper.HashToken(req.RefreshToken))
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrInvalidRefreshToken
+		}
+		return shared.ErrFailedLogout
+	}
+
+	if refresh.UserId != principal.UserId {
+		return shared.ErrInvalidRefreshToken
+	}
+
+	if err := uu.tokenRepo.RevokeRefreshTokenFamily(ctx, refresh.FamilyId); err != nil {
+		return shared.ErrFailedLogout
+	}
+
+	return nil
+}
+
+func (uu *userUsecase) IsTokenRevoked(ctx context.Context, jti string, userId uint) (bool, error) {
+	return uu.tokenRepo.IsAccessTokenRevoked(ctx, jti, userId)
+}
+
+func (uu *userUsecase) DeleteUser(ctx context.Context, id uint) error {
+	if err := uu.userRepo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, shared.ErrRecordNotFound):
+			return shared.ErrUserNotFound
+		case errors.Is(err, shared.ErrLastOwner):
+			return shared.ErrLastOwner
+		}
+		return shared.ErrDeletingUser
+	}
+
+	return nil
+}
+
+func (uu *userUsecase) GetDeletedUsers(ctx context.Context, query dto.PaginationQuery) ([]dto.DeletedUserDTO, dto.PaginationResponse, error) {
+	query.Normalize()
+
+	deleted, total, err := uu.userRepo.FindDeleted(ctx, query)
+	if err != nil {
+		return nil, dto.PaginationResponse{}, shared.ErrGettingDeletedRecords
+	}
+
+	users := []dto.DeletedUserDTO{}
+	for _, user := range deleted {
+		users = append(users, toDeletedUserDTO(user))
+	}
+
+	return users, dto.NewPaginationResponse(query, total), nil
+}
+
+func (uu *userUsecase) RestoreUser(ctx context.Context, id uint) (dto.DeletedUserDTO, error) {
+	user, err := uu.userRepo.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.DeletedUserDTO{}, shared.ErrUserNotFound
+		}
+		if errors.Is(err, shared.ErrEmailTaken) {
+			return dto.DeletedUserDTO{}, shared.ErrEmailTaken
+		}
+		return dto.DeletedUserDTO{}, shared.ErrRestoringUser
+	}
+
+	return toDeletedUserDTO(user), nil
+}
+
+func (uu *userUsecase) GetProfile(ctx context.Context, id uint) (dto.UserResponse, error) {
+	user, err := uu.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.UserResponse{}, shared.ErrUserNotFound
+		}
+		return dto.UserResponse{}, shared.ErrGettingProfile
+	}
+
+	return toUserResponse(user), nil
+}
+
+// UpdateProfile applies the fields present in req. A new email is not taken
+// over right away: a confirmation token is sent to it and the email changes
+// once ConfirmEmail is called with that token.
+func (uu *userUsecase) UpdateProfile(ctx context.Context, id uint, req dto.UpdateProfileRequest) (dto.UpdateProfileResponse, error) {
+	user, err := uu.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.UpdateProfileResponse{}, shared.ErrUserNotFound
+		}
+		return dto.UpdateProfileResponse{}, shared.ErrUpdatingProfile
+	}
+
+	pendingEmail := ""
+	if req.Email != nil && *req.Email != user.Email {
+		if err := uu.requestEmailChange(ctx, user, *req.Email); err != nil {
+			return dto.UpdateProfileResponse{}, err
+		}
+		pendingEmail = *req.Email
+	}
+
+	fields := []string{}
+	if req.Name != nil {
+		user.Name = *req.Name
+		fields = append(fields, "Name")
+	}
+	if req.Phone != nil {
+		user.Phone = *req.Phone
+		fields = append(fields, "Phone")
+	}
+	if req.CurrentJob != nil {
+		user.CurrentJob = *req.CurrentJob
+		fields = append(fields, "CurrentJob")
+	}
+	if req.Age != nil {
+		user.Age = *req.Age
+		fields = append(fields, "Age")
+	}
+
+	if len(fields) > 0 {
+		user, err = uu.userRepo.Update(ctx, user, fields)
+		if err != nil {
+			if errors.Is(err, shared.ErrRecordNotFound) {
+				return dto.UpdateProfileResponse{}, shared.ErrUserNotFound
+			}
+			return dto.UpdateProfileResponse{}, shared.ErrUpdatingProfile
+		}
+	}
+
+	return dto.UpdateProfileResponse{
+		UserResponse: toUserResponse(user),
+		PendingEmail: pendingEmail,
+	}, nil
+}
+
+func (uu *userUsecase) requestEmailChange(ctx context.Context, user model.Users, email string) error {
+	_, err := uu.userRepo.FindByEmail(ctx, email)
+	if err == nil {
+		return shared.ErrEmailTaken
+	}
+	if !errors.Is(err, shared.ErrRecordNotFound) {
+		return shared.ErrUpdatingProfile
+	}
+
+	token, err := helper.RandomToken(32)
+	if err != nil {
+		return shared.ErrUpdatingProfile
+	}
+
+	_, err = uu.tokenRepo.CreateUserToken(ctx, model.UserTokens{
+		UserId:    user.ID,
+		Purpose:   model.UserTokenPurposeEmailChange,
+		TokenHash: helper.HashToken(token),
+		Email:     email,
+		ExpiresAt: time.Now().Add(emailTokenTTL),
+	})
+	if err != nil {
+		return shared.ErrUpdatingProfile
+	}
+
+	err = uu.notifier.Notify(ctx, notify.Message{
+		To:      email,
+		Subject: "Confirm your new email",
+		Body:    "Confirm this address for your job portal account with the token " + token + ". It expires in 24 hours.",
+	})
+	if err != nil {
+		return shared.ErrUpdatingProfile
+	}
+
+	return nil
+}
+
+func (uu *userUsecase) ConfirmEmail(ctx context.Context, req dto.ConfirmEmailRequest) (dto.UserResponse, error) {
+	user, err := uu.userRepo.ChangeEmail(ctx, helper.HashToken(req.Token))
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.UserResponse{}, shared.ErrInvalidEmailToken
+		}
+		if errors.Is(err, shared.ErrEmailTaken) {
+			return dto.UserResponse{}, shared.ErrEmailTaken
+		}
+		return dto.UserResponse{}, shared.ErrChangingEmail
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (uu *userUsecase) GetPublicProfile(ctx context.Context, id uint) (dto.PublicProfileResponse, error) {
+	user, err := uu.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.PublicProfileResponse{}, shared.ErrUserNotFound
+		}
+		return dto.PublicProfileResponse{}, shared.ErrGettingProfile
+	}
+
+	return dto.PublicProfileResponse{
+		ID:         user.ID,
+		Name:       user.Name,
+		CurrentJob: user.CurrentJob,
+		Age:        user.Age,
+	}, nil
+}
+
+// ChangePassword sets a new password for the principal and ends every session
+// of the user, including the access token the request was made with.
+func (uu *userUsecase) ChangePassword(ctx context.Context, req dto.ChangePasswordRequest, principal auth.Principal) error {
+	id := principal.UserId
+	user, err := uu.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrUserNotFound
+		}
+		return shared.ErrChangingPassword
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.CurrentPassword))
+	if err != nil {
+		return shared.ErrWrongPassword
+	}
+
+	hash, err := uu.hashPassword(req.NewPassword)
+	if err != nil {
+		return shared.ErrChangingPassword
 	}
 
+	if err := uu.userRepo.UpdatePassword(ctx, id, hash); err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrUserNotFound
+		}
+		return shared.ErrChangingPassword
+	}
+
+	if principal.TokenId != "" {
+		if err := uu.tokenRepo.RevokeAccessToken(ctx, principal.TokenId, principal.TokenExpiresAt); err != nil {
+			return shared.ErrChangingPassword
+		}
+	}
+
+	return nil
+}
+
+// ForgotPassword sends a reset token to the account with the given email. It
+// succeeds whether or not there is such an account, so the endpoint cannot be
+// used to find out which emails are registered.
+func (uu *userUsecase) ForgotPassword(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	user, err := uu.userRepo.FindByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return nil
+		}
+		return shared.ErrRequestingPasswordReset
+	}
+
+	token, err := helper.RandomToken(32)
+	if err != nil {
+		return shared.ErrRequestingPasswordReset
+	}
+
+	_, err = uu.tokenRepo.CreateUserToken(ctx, model.UserTokens{
+		UserId:    user.ID,
+		Purpose:   model.UserTokenPurposePasswordReset,
+		TokenHash: helper.HashToken(token),
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
+	})
+	if err != nil {
+		return shared.ErrRequestingPasswordReset
+	}
+
+	err = uu.notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body:    "Reset the password of your job portal account with the token " + token + ". It expires in 1 hour. If you did not ask for this, ignore this message.",
+	})
+	if err != nil {
+		return shared.ErrRequestingPasswordReset
+	}
+
+	return nil
+}
+
+func (uu *userUsecase) ResetPassword(ctx context.Context, req dto.ResetPasswordRequest) error {
+	hash, err := uu.hashPassword(req.NewPassword)
+	if err != nil {
+		return shared.ErrResettingPassword
+	}
+
+	if _, err := uu.userRepo.ResetPassword(ctx, helper.HashToken(req.Token), hash); err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrInvalidPasswordResetToken
+		}
+		return shared.ErrResettingPassword
+	}
+
+	return nil
+}
+
+// VerifyEmail checks a link sent by sendVerification and marks the email in
+// it verified, as long as it is still the user's email.
+func (uu *userUsecase) VerifyEmail(ctx context.Context, link url.Values) (dto.UserResponse, error) {
+	if err := uu.links.Verify(link, time.Now()); err != nil {
+		if errors.Is(err, helper.ErrLinkExpired) {
+			return dto.UserResponse{}, shared.ErrVerificationLinkExpired
+		}
+		return dto.UserResponse{}, shared.ErrInvalidVerificationLink
+	}
+
+	id, err := strconv.ParseUint(link.Get("user"), 10, 0)
+	if err != nil {
+		return dto.UserResponse{}, shared.ErrInvalidVerificationLink
+	}
+
+	user, err := uu.userRepo.MarkEmailVerified(ctx, uint(id), link.Get("email"))
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return dto.UserResponse{}, shared.ErrInvalidVerificationLink
+		}
+		return dto.UserResponse{}, shared.ErrVerifyingEmail
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (uu *userUsecase) ResendVerification(ctx context.Context, id uint) error {
+	user, err := uu.userRepo.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, shared.ErrRecordNotFound) {
+			return shared.ErrUserNotFound
+		}
+		return shared.ErrSendingVerification
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return shared.ErrEmailAlreadyVerified
+	}
+
+	if err := uu.sendVerification(ctx, user); err != nil {
+		return shared.ErrSendingVerification
+	}
+
+	return nil
+}
+
+// sendVerification mails the user a signed link that proves they own their
+// current email.
+func (uu *userUsecase) sendVerification(ctx context.Context, user model.Users) error {
+	link := uu.links.Sign(url.Values{
+		"user":  {strconv.FormatUint(uint64(user.ID), 10)},
+		"email": {user.Email},
+	}, time.Now().Add(uu.email.VerifyTTL))
+
+	return uu.notifier.Notify(ctx, notify.Message{
+		To:      user.Email,
+		Subject: "Verify your email",
+		Body:    "Open " + uu.email.VerifyURL + "?" + link.Encode() + " to verify the email of your job portal account.",
+	})
+}
+
+func (uu *userUsecase) hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), uu.passwords.BcryptCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// upgradePasswordHash rehashes the password the user just logged in with if
+// it was stored with a lower cost than configured. A failure is not the
+// user's problem, so it only means the upgrade is retried on the next login.
+func (uu *userUsecase) upgradePasswordHash(ctx context.Context, user model.Users, password string) {
+	cost, err := bcrypt.Cost([]byte(user.Password))
+	if err != nil || cost >= uu.passwords.BcryptCost {
+		return
+	}
+
+	hash, err := uu.hashPassword(password)
+	if err != nil {
+		return
+	}
+
+	user.Password = hash
+	uu.userRepo.Update(ctx, user, []string{"Password"})
+}
+
+func toUserResponse(user model.Users) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		Phone:         user.Phone,
+		CurrentJob:    user.CurrentJob,
+		Age:           user.Age,
+		IsJobPoster:   user.IsJobPoster,
+	}
+}
+
+func toDeletedUserDTO(user model.Users) dto.DeletedUserDTO {
+	return dto.DeletedUserDTO{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      userRole(user),
+		DeletedAt: DeletedAtStrConv(user.DeletedAt),
+	}
+}
+
+func (uu *userUsecase) accessToken(user model.Users) (string, error) {
+	role := userRole(user)
 	claims := helper.JWTClaims{
-		UserId: user.ID,
+		UserId:        user.ID,
+		Role:          role,
+		Permissions:   auth.PermissionsOf(role),
+		EmailVerified: user.EmailVerifiedAt != nil,
 	}
 
 	userPayload := dto.UserPayload{
@@ -89,14 +619,44 @@ func (uu *userUsecase) LoginUser(ctx context.Context, req dto.LoginRequest) (dto
 		Password: user.Password,
 	}
 
-	token, err := helper.AuthorizedJWT(claims, userPayload)
+	return uu.tokens.AuthorizedJWT(claims, userPayload)
+}
+
+// newRefreshToken returns a fresh opaque refresh token for the session
+// familyId together with the row to store for it.
+func (uu *userUsecase) newRefreshToken(userId uint, familyId string) (string, model.RefreshTokens, error) {
+	token, err := helper.RandomToken(32)
 	if err != nil {
-		return output, shared.ErrFailedLogin
+		return "", model.RefreshTokens{}, err
 	}
 
-	output = dto.LoginResponse{
-		AccessToken: token,
+	refresh := model.RefreshTokens{
+		UserId:    userId,
+		TokenHash: helper.HashToken(token),
+		FamilyId:  familyId,
+		ExpiresAt: time.Now().Add(uu.tokens.RefreshTokenTTL()),
 	}
 
-	return output, nil
+	return token, refresh, nil
+}
+
+func (uu *userUsecase) loginResponse(accessToken string, refreshToken string) dto.LoginResponse {
+	return dto.LoginResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(uu.tokens.AccessTokenTTL().Seconds()),
+	}
+}
+
+// userRole falls back to is_job_poster for accounts created before roles
+// were stored.
+func userRole(user model.Users) string {
+	if user.Role != "" {
+		return user.Role
+	}
+	if user.IsJobPoster {
+		return auth.RolePoster
+	}
+	return auth.RoleSeeker
 }
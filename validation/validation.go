@@ -0,0 +1,207 @@
+// Package validation registers the custom binding rules of the API and turns
+// binding failures into field level errors.
+package validation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/i18n"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// Codes of shared.FieldError.
+const (
+	CodeRequired        = "required"
+	CodeInvalidEmail    = "invalid_email"
+	CodeInvalidPhone    = "invalid_phone"
+	CodeInvalidDateTime = "invalid_datetime"
+	CodeNotInFuture     = "not_in_future"
+	CodeTooShort        = "too_short"
+	CodeTooLong         = "too_long"
+	CodeTooSmall        = "too_small"
+	CodeTooLarge        = "too_large"
+	CodeInvalidChoice   = "invalid_choice"
+	CodeInvalidType     = "invalid_type"
+	CodeInvalid         = "invalid"
+	CodeUnknownField    = "unknown_field"
+)
+
+// phonePattern accepts an optional leading + and 8 to 15 digits, the range
+// E.164 allows, with spaces or dashes between digit groups.
+var phonePattern = regexp.MustCompile(`^\+?[0-9](?:[ -]?[0-9]){7,14}$`)
+
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	v.RegisterTagNameFunc(fieldName)
+	v.RegisterValidation("phone", func(fl validator.FieldLevel) bool {
+		return phonePattern.MatchString(fl.Field().String())
+	})
+	v.RegisterValidation("future", func(fl validator.FieldLevel) bool {
+		return isFuture(fl.Field(), time.Now())
+	})
+}
+
+// fieldName reports a field by the name clients send it under.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func isFuture(field reflect.Value, now time.Time) bool {
+	switch v := field.Interface().(type) {
+	case time.Time:
+		return v.After(now)
+	case string:
+		t, err := time.Parse(dto.DateTimeLayout, v)
+		return err == nil && t.After(now)
+	default:
+		return false
+	}
+}
+
+// Translate turns the error of a ShouldBind call into a
+// *shared.ValidationError naming each rejected field. Errors that are not
+// about a particular field, such as malformed JSON, become fallback.
+func Translate(err error, fallback *shared.CustomError) error {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]shared.FieldError, 0, len(invalid))
+		for _, fe := range invalid {
+			fields = append(fields, fieldError(fe))
+		}
+		return shared.NewValidationError(fields...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return shared.NewValidationError(NewFieldError(typeErr.Field, CodeInvalidType, jsonType(typeErr.Type)))
+	}
+
+	return fallback
+}
+
+// DecodeMergePatch decodes the JSON Merge Patch (RFC 7396) data into patch,
+// a pointer to a struct of pointer fields, and validates it. Members patch
+// has no field for are rejected, and so is null: the fields patched this way
+// cannot be removed.
+func DecodeMergePatch(data []byte, patch interface{}) error {
+	members := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return Translate(err, shared.ErrInvalidRequestBody)
+	}
+
+	known := map[string]bool{}
+	t := reflect.TypeOf(patch).Elem()
+	for i := 0; i < t.NumField(); i++ {
+		known[fieldName(t.Field(i))] = true
+	}
+
+	names := make([]string, 0, len(members))
+	for name := range members {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := []shared.FieldError{}
+	for _, name := range names {
+		switch {
+		case !known[name]:
+			fields = append(fields, NewFieldError(name, CodeUnknownField))
+		case string(bytes.TrimSpace(members[name])) == "null":
+			fields = append(fields, NewFieldError(name, CodeRequired))
+		}
+	}
+	if len(fields) > 0 {
+		return shared.NewValidationError(fields...)
+	}
+
+	if err := json.Unmarshal(data, patch); err != nil {
+		return Translate(err, shared.ErrInvalidRequestBody)
+	}
+	if err := binding.Validator.ValidateStruct(patch); err != nil {
+		return Translate(err, shared.ErrInvalidRequestBody)
+	}
+	return nil
+}
+
+func fieldError(fe validator.FieldError) shared.FieldError {
+	field := fe.Field()
+
+	switch fe.Tag() {
+	case "required":
+		return NewFieldError(field, CodeRequired)
+	case "email":
+		return NewFieldError(field, CodeInvalidEmail)
+	case "phone":
+		return NewFieldError(field, CodeInvalidPhone)
+	case "datetime":
+		return NewFieldError(field, CodeInvalidDateTime, fe.Param())
+	case "future":
+		return NewFieldError(field, CodeNotInFuture)
+	case "oneof":
+		return NewFieldError(field, CodeInvalidChoice, strings.ReplaceAll(fe.Param(), " ", ", "))
+	case "min", "gte", "gt":
+		if fe.Kind() == reflect.String {
+			return NewFieldError(field, CodeTooShort, fe.Param())
+		}
+		return NewFieldError(field, CodeTooSmall, fe.Param())
+	case "max", "lte", "lt":
+		if fe.Kind() == reflect.String {
+			return NewFieldError(field, CodeTooLong, fe.Param())
+		}
+		return NewFieldError(field, CodeTooLarge, fe.Param())
+	}
+
+	return NewFieldError(field, CodeInvalid)
+}
+
+// NewFieldError rejects field with code. args fill the message template of
+// code after the field name; the message is English and translated when
+// the error is reported.
+func NewFieldError(field, code string, args ...interface{}) shared.FieldError {
+	return shared.FieldError{
+		Field:   field,
+		Code:    code,
+		Message: i18n.Translate(i18n.DefaultLanguage, "field."+code, append([]interface{}{field}, args...)...),
+		Args:    args,
+	}
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "list"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,78 @@
+package validation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/adityatresnobudi/job-portal/dto"
+	"github.com/adityatresnobudi/job-portal/shared"
+	"github.com/adityatresnobudi/job-portal/validation"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func codes(t *testing.T, err error) map[string]string {
+	var invalid *shared.ValidationError
+	require.ErrorAs(t, err, &invalid)
+
+	byField := map[string]string{}
+	for _, f := range invalid.Fields {
+		byField[f.Field] = f.Code
+	}
+	return byField
+}
+
+func TestTranslate(t *testing.T) {
+	t.Run("should report every invalid field of a registration", func(t *testing.T) {
+		payload := dto.UserPayload{
+			Name:     "",
+			Email:    "not-an-email",
+			Phone:    "12ab",
+			Password: "short",
+			Age:      12,
+		}
+
+		err := validation.Translate(binding.Validator.ValidateStruct(payload), shared.ErrInvalidRequestBody)
+
+		assert.Equal(t, map[string]string{
+			"user_name":     validation.CodeRequired,
+			"email":         validation.CodeInvalidEmail,
+			"phone":         validation.CodeInvalidPhone,
+			"user_password": validation.CodeTooShort,
+			"user_age":      validation.CodeTooSmall,
+		}, codes(t, err))
+	})
+
+	t.Run("should accept common phone formats", func(t *testing.T) {
+		for _, phone := range []string{"+6281234567890", "081234567890", "0812-3456-7890", "+62 812 3456 7890"} {
+			payload := dto.UserPayload{Name: "Seeker", Email: "seeker@mail.com", Phone: phone, Password: "secret123"}
+
+			assert.NoError(t, binding.Validator.ValidateStruct(payload), phone)
+		}
+	})
+
+	t.Run("should require a well formed expiry date in the future and a positive quota", func(t *testing.T) {
+		payload := dto.JobsPayload{OrganizationId: 1, JobName: "job", JobDesc: "desc", Quota: 0, ExpiryDate: "2000-01-01 00:00:00"}
+
+		err := validation.Translate(binding.Validator.ValidateStruct(payload), shared.ErrInvalidRequestBody)
+
+		assert.Equal(t, map[string]string{
+			"quota":       validation.CodeTooSmall,
+			"expiry_date": validation.CodeNotInFuture,
+		}, codes(t, err))
+
+		payload.Quota = 1
+		payload.ExpiryDate = "tomorrow"
+
+		err = validation.Translate(binding.Validator.ValidateStruct(payload), shared.ErrInvalidRequestBody)
+
+		assert.Equal(t, map[string]string{"expiry_date": validation.CodeInvalidDateTime}, codes(t, err))
+	})
+
+	t.Run("should fall back for errors not about a field", func(t *testing.T) {
+		err := validation.Translate(errors.New("unexpected EOF"), shared.ErrInvalidRequestBody)
+
+		assert.Equal(t, shared.ErrInvalidRequestBody, err)
+	})
+}